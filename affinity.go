@@ -0,0 +1,101 @@
+package poolswap
+
+import "sync"
+
+// affinity parks drained objects per key so that GetForKey can hand the same
+// instance back to the same key. It is bounded to maxKeys tracked objects;
+// anything beyond that goes through the regular free list.
+type affinity[T any] struct {
+	mu      sync.Mutex
+	maxKeys int
+	idle    map[uint64]*T // key -> drained object waiting for that key
+	owner   map[*T]uint64 // object handed out by GetForKey -> its key
+}
+
+func newAffinity[T any](maxKeys int) *affinity[T] {
+	return &affinity[T]{
+		mu:      sync.Mutex{},
+		maxKeys: maxKeys,
+		idle:    make(map[uint64]*T),
+		owner:   make(map[*T]uint64),
+	}
+}
+
+// take removes and returns the object parked for key, or nil.
+func (a *affinity[T]) take(key uint64) *T {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	obj, ok := a.idle[key]
+	if !ok {
+		return nil
+	}
+	delete(a.idle, key)
+	a.owner[obj] = key
+
+	return obj
+}
+
+// bind associates a freshly acquired object with key, if there is room.
+func (a *affinity[T]) bind(obj *T, key uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.idle)+len(a.owner) < a.maxKeys {
+		a.owner[obj] = key
+	}
+}
+
+// park keeps a drained, reset object for its key.
+// It returns the object that should go to the regular free list instead
+// (the given one if it has no key, or one it displaced), or nil.
+func (a *affinity[T]) park(obj *T) *T {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key, ok := a.owner[obj]
+	if !ok {
+		return obj
+	}
+	delete(a.owner, obj)
+	displaced := a.idle[key]
+	a.idle[key] = obj
+
+	return displaced
+}
+
+// forget drops any association of a discarded object.
+func (a *affinity[T]) forget(obj *T) {
+	a.mu.Lock()
+	delete(a.owner, obj)
+	a.mu.Unlock()
+}
+
+// drain drops all parked objects.
+func (a *affinity[T]) drain() {
+	a.mu.Lock()
+	clear(a.idle)
+	a.mu.Unlock()
+}
+
+// GetForKey is like Get, but prefers the object most recently released
+// after being acquired with the same key, keeping per-key state (and cache lines) warm.
+// When no such object is parked, it falls back to Get.
+//
+// Requires the pool to be created with WithAffinity; otherwise it is equivalent to Get.
+func (p *Pool[T, PT]) GetForKey(key uint64) *T {
+	if p.affinity == nil {
+		return p.Get()
+	}
+	if obj := p.affinity.take(key); obj != nil && p.reusable(obj) {
+		p.handOut(obj)
+
+		return obj
+	}
+	obj := p.Get()
+	if obj != nil {
+		p.affinity.bind(obj, key)
+	}
+
+	return obj
+}
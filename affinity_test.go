@@ -0,0 +1,75 @@
+package poolswap_test
+
+import (
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func newAffinityPool(maxKeys int) *poolswap.Pool[MockPayload, *MockPayload] {
+	return poolswap.NewPool(
+		func() *MockPayload { return &MockPayload{} },
+		func(_ *MockPayload) bool { return true },
+		poolswap.WithAffinity(maxKeys),
+	)
+}
+
+func TestGetForKey_ReusesSameObject(t *testing.T) {
+	pool := newAffinityPool(8)
+
+	first := pool.GetForKey(1)
+	pool.Release(first)
+
+	for range 10 {
+		obj := pool.GetForKey(1)
+		if obj != first {
+			t.Fatal("GetForKey should return the object last released for the same key")
+		}
+		if obj.DebugPeekRef() != 1 {
+			t.Fatalf("object from GetForKey should have Ref=1, got %d", obj.DebugPeekRef())
+		}
+		pool.Release(obj)
+	}
+
+	other := pool.GetForKey(2)
+	if other == first {
+		t.Fatal("GetForKey must not hand out another key's parked object")
+	}
+	pool.Release(other)
+}
+
+func TestGetForKey_MostRecent(t *testing.T) {
+	pool := newAffinityPool(8)
+
+	a := pool.GetForKey(1)
+	b := pool.GetForKey(1)
+	pool.Release(a)
+	pool.Release(b)
+
+	if got := pool.GetForKey(1); got != b {
+		t.Fatal("GetForKey should prefer the most recently released object for the key")
+	}
+}
+
+func TestGetForKey_Bounded(t *testing.T) {
+	pool := newAffinityPool(1)
+
+	a := pool.GetForKey(1)
+	b := pool.GetForKey(2) // over the bound: not tracked
+	pool.Release(a)
+	pool.Release(b)
+
+	if got := pool.GetForKey(1); got != a {
+		t.Fatal("tracked key should get its object back")
+	}
+}
+
+func TestGetForKey_WithoutAffinity(t *testing.T) {
+	pool := newMockPool()
+
+	obj := pool.GetForKey(1)
+	if obj == nil || obj.DebugPeekRef() != 1 {
+		t.Fatal("GetForKey without WithAffinity should behave like Get")
+	}
+	pool.Release(obj)
+}
@@ -0,0 +1,139 @@
+package poolswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPoolEmpty is returned by GetContext for a pool without a factory
+// (and without WithMaxTotal) that has nothing to recycle.
+var ErrPoolEmpty = errors.New("poolswap: pool has no object to hand out")
+
+// capacity counts a pool's objects in use (handed out and not yet drained) against
+// a bound (WithMaxTotal), and wakes GetContext waiters when one comes back.
+type capacity struct {
+	mu    sync.Mutex
+	max   int
+	inUse int
+	wake  chan struct{} // closed by signal; nil while nobody waits
+}
+
+func newCapacity(maxTotal int) *capacity {
+	return &capacity{
+		mu:    sync.Mutex{},
+		max:   maxTotal,
+		inUse: 0,
+		wake:  nil,
+	}
+}
+
+// take counts an object as in use, regardless of the bound.
+func (c *capacity) take() {
+	c.mu.Lock()
+	c.inUse++
+	c.mu.Unlock()
+}
+
+// reserve counts an object as in use if that stays within the bound.
+func (c *capacity) reserve() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.inUse >= c.max {
+		return false
+	}
+	c.inUse++
+
+	return true
+}
+
+// done counts an object as no longer in use and wakes the waiters.
+func (c *capacity) done() {
+	c.mu.Lock()
+	c.inUse--
+	c.mu.Unlock()
+	c.signal()
+}
+
+// waiter returns a channel that is closed by the next signal.
+func (c *capacity) waiter() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.wake == nil {
+		c.wake = make(chan struct{})
+	}
+
+	return c.wake
+}
+
+// signal wakes all current waiters.
+func (c *capacity) signal() {
+	c.mu.Lock()
+	if c.wake != nil {
+		close(c.wake)
+		c.wake = nil
+	}
+	c.mu.Unlock()
+}
+
+// WithMaxTotal bounds the number of objects the pool has handed out and that have
+// not been drained yet to n, turning it into a bounded resource pool: GetContext blocks
+// while n objects are in use. Since objects are only allocated when none is idle,
+// the pool never holds more than n objects it allocated itself (idle or in use).
+//
+// Get does not block: at the bound, it hands out an idle object or allocates
+// beyond the bound (the excess counts as in use until it drains, so GetContext waits
+// for it too). Use GetContext wherever the bound must hold.
+//
+// Objects that are never released (nor retired from a container) keep their slot forever.
+func WithMaxTotal(n int) PoolOption {
+	return func(o *poolOptions) {
+		o.maxTotal = max(n, 0)
+		o.boundTotal = true
+	}
+}
+
+// GetContext is Get for pools bounded by WithMaxTotal: if the pool has no idle object
+// and the bound is reached, it blocks until an object is released (or fed via Put)
+// or ctx is done, in which case it returns an error wrapping ctx.Err().
+// A pool without a factory can only be waited on for released objects.
+//
+// Without WithMaxTotal, GetContext is equivalent to Get, but returns ErrPoolEmpty
+// instead of nil if the pool has nothing to recycle and no factory.
+func (p *Pool[T, PT]) GetContext(ctx context.Context) (*T, error) {
+	if p.limit == nil {
+		obj := p.Get()
+		if obj == nil {
+			return nil, ErrPoolEmpty
+		}
+
+		return obj, nil
+	}
+	for {
+		wake := p.limit.waiter() // before looking, so that no signal is missed
+		if obj := p.takeAny(); obj != nil {
+			p.handOut(obj)
+
+			return obj, nil
+		}
+		if p.newFunc() != nil && p.limit.reserve() {
+			obj := p.construct()
+			if obj == nil { // the factory gave up (see WithNoPanic)
+				p.checkIn()
+
+				return nil, ErrPoolEmpty
+			}
+			p.prepare(obj)
+
+			return obj, nil
+		}
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("poolswap: waiting for an object: %w", ctx.Err())
+		}
+	}
+}
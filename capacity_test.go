@@ -0,0 +1,140 @@
+package poolswap_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestGetContext_BlocksAtMaxTotal(t *testing.T) {
+	pool := poolswap.NewPool(
+		func() *MockPayload { return new(MockPayload) },
+		func(*MockPayload) bool { return true },
+		poolswap.WithMaxTotal(2),
+		poolswap.WithMaxIdle(2), // deterministic free list: sync.Pool may drop objects
+	)
+	ctx := context.Background()
+	a, _ := pool.GetContext(ctx)
+	b, _ := pool.GetContext(ctx)
+
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	obj, err := pool.GetContext(short)
+	if !errors.Is(err, context.DeadlineExceeded) || obj != nil {
+		t.Fatalf("GetContext at the bound should block until ctx is done, got %v, %v", obj, err)
+	}
+
+	got := make(chan *MockPayload)
+	go func() {
+		obj, _ := pool.GetContext(ctx)
+		got <- obj
+	}()
+	select {
+	case <-got:
+		t.Fatal("GetContext returned while the pool was at its bound")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	pool.Release(b)
+	select {
+	case obj := <-got:
+		if obj != b {
+			t.Fatal("the waiter should get the released object")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GetContext should unblock on Release")
+	}
+	if s := pool.Stats(); s.News != 2 {
+		t.Fatalf("no object should be allocated beyond the bound, got %+v", s)
+	}
+
+	pool.Release(a)
+	pool.Release(b)
+}
+
+func TestGetContext_UnblocksOnPut(t *testing.T) {
+	pool := poolswap.NewPool[MockPayload](
+		nil,
+		func(*MockPayload) bool { return true },
+		poolswap.WithMaxTotal(1),
+		poolswap.WithMaxIdle(1),
+	)
+
+	got := make(chan *MockPayload)
+	go func() {
+		obj, _ := pool.GetContext(context.Background())
+		got <- obj
+	}()
+
+	obj := new(MockPayload)
+	time.Sleep(10 * time.Millisecond)
+	pool.Put(obj)
+	select {
+	case g := <-got:
+		if g != obj {
+			t.Fatal("the waiter should get the object fed via Put")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GetContext should unblock on Put")
+	}
+}
+
+func TestGetContext_GetExceedsBound(t *testing.T) {
+	pool := poolswap.NewPool(
+		func() *MockPayload { return new(MockPayload) },
+		func(*MockPayload) bool { return true },
+		poolswap.WithMaxTotal(1),
+		poolswap.WithMaxIdle(1),
+	)
+	a := pool.Get()
+	b := pool.Get() // Get does not block: allocates beyond the bound
+	if a == nil || b == nil || a == b {
+		t.Fatal("Get should allocate beyond the bound")
+	}
+
+	pool.Release(a)
+	short, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	obj, err := pool.GetContext(short)
+	if err != nil || obj != a {
+		t.Fatalf("an idle object should be handed out even above the bound, got %v, %v", obj, err)
+	}
+}
+
+func TestGetContext_Unbounded(t *testing.T) {
+	pool := poolswap.NewPool[MockPayload](nil, func(*MockPayload) bool { return true })
+	_, err := pool.GetContext(context.Background())
+	if !errors.Is(err, poolswap.ErrPoolEmpty) {
+		t.Fatalf("want ErrPoolEmpty from an empty pool without a factory, got %v", err)
+	}
+}
+
+func TestWithMaxTotal_AdoptedObjectIsNotCounted(t *testing.T) {
+	pool := poolswap.NewPool(
+		func() *MockPayload { return new(MockPayload) },
+		func(obj *MockPayload) bool { return obj.ID == 0 }, // discard the adopted object
+		poolswap.WithMaxTotal(1),
+		poolswap.WithMaxIdle(2),
+	)
+	container := poolswap.NewContainer(pool, &MockPayload{ID: 1})
+	ctx := context.Background()
+	a, _ := pool.GetContext(ctx)
+	container.Update(a) // drains the adopted initial object
+
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	obj, err := pool.GetContext(short)
+	if !errors.Is(err, context.DeadlineExceeded) || obj != nil {
+		t.Fatalf("draining an adopted object must not free a slot, got %v, %v", obj, err)
+	}
+	container.Close()
+
+	obj, err = pool.GetContext(ctx)
+	if err != nil || obj != a {
+		t.Fatalf("want the released object, got %v, %v", obj, err)
+	}
+	pool.Release(obj)
+}
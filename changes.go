@@ -0,0 +1,71 @@
+package poolswap
+
+import (
+	"context"
+	"iter"
+)
+
+// Changes returns an iterator over the container's current objects: it yields the
+// current object (if any) right away, and then the new current object after every
+// change, until ctx is done, the container is closed, or the loop body breaks:
+//
+//	for gen, cfg := range c.Changes(ctx) {
+//		apply(gen, cfg)
+//	}
+//
+// Each object is acquired for the duration of the loop body and released when the body
+// returns (including by panic), so it must not be retained beyond that; Acquire it
+// again to keep it. Generations missed while the body runs are coalesced: the next
+// iteration yields the latest one only. Empty states (Update(nil)) are skipped.
+func (c *Container[T, PT]) Changes(ctx context.Context) iter.Seq2[uint64, *T] {
+	return func(yield func(uint64, *T) bool) {
+		wake := make(chan struct{}, 1)
+		sub := c.SubscribeWeak(func() {
+			select {
+			case wake <- struct{}{}:
+			default: // a wake-up is pending already
+			}
+		})
+		defer sub.Stop()
+
+		var seen changeMark[T]
+		for c.yieldChange(yield, &seen) {
+			select {
+			case <-wake:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// changeMark identifies the last object yielded by Changes.
+type changeMark[T any] struct {
+	obj *T
+	gen uint64
+}
+
+// yieldChange yields the current object unless it is the one yielded last,
+// reporting whether Changes should go on.
+func (c *Container[T, PT]) yieldChange(yield func(uint64, *T) bool, seen *changeMark[T]) bool {
+	obj, gen := c.AcquireWithGeneration()
+	switch {
+	case obj == nil:
+		return c.Status() == StatusActive
+	case obj == seen.obj && gen == seen.gen: // woken up for a change we have seen
+		c.Release(obj)
+
+		return true
+	default:
+		*seen = changeMark[T]{obj: obj, gen: gen}
+
+		return c.yieldAcquired(yield, gen, obj)
+	}
+}
+
+// yieldAcquired passes an acquired object to yield and releases it afterwards.
+func (c *Container[T, PT]) yieldAcquired(yield func(uint64, *T) bool, gen uint64, obj *T) bool {
+	defer c.Release(obj)
+
+	return yield(gen, obj)
+}
@@ -0,0 +1,93 @@
+package poolswap_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestChanges(t *testing.T) {
+	pool := newMockPool()
+	first := pool.Get()
+	container := poolswap.NewContainer(pool, first)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type change struct {
+		gen  uint64
+		obj  *MockPayload
+		refs int64
+	}
+	changes := make(chan change)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for gen, obj := range container.Changes(ctx) {
+			changes <- change{gen: gen, obj: obj, refs: obj.DebugPeekRef()}
+		}
+	}()
+
+	if got := <-changes; got.gen != 0 || got.obj != first || got.refs != 2 {
+		t.Fatalf("the current object should be yielded first, acquired: %+v", got)
+	}
+
+	next := pool.Get()
+	next.Recycled.Store(false)
+	container.Update(next)
+	if got := <-changes; got.gen != 1 || got.obj != next || got.refs != 2 {
+		t.Fatalf("the update should be yielded, acquired: %+v", got)
+	}
+	if !first.Recycled.Load() {
+		t.Fatal("the yielded object should be released once the body returned")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the iterator should stop when ctx is cancelled")
+	}
+	if next.DebugPeekRef() != 1 {
+		t.Fatalf("no reference should be left behind, Ref=%d", next.DebugPeekRef())
+	}
+}
+
+func TestChanges_CoalescesAndStopsOnClose(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewEmptyContainer(pool)
+
+	yielded := make(chan uint64)
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for gen := range container.Changes(context.Background()) {
+			yielded <- gen
+			if gen == 1 {
+				<-release // hold the body while more updates land
+			}
+		}
+	}()
+
+	container.Update(pool.Get())
+	if gen := <-yielded; gen != 1 {
+		t.Fatalf("want generation 1 first, got %d", gen)
+	}
+	for range 5 {
+		container.Update(pool.Get()) // generations 2-6 arrive while the body for 1 runs
+	}
+	close(release)
+	if gen := <-yielded; gen != 6 {
+		t.Fatalf("missed generations should be coalesced to the latest (6), got %d", gen)
+	}
+
+	container.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the iterator should stop when the container is closed")
+	}
+}
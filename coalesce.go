@@ -0,0 +1,49 @@
+package poolswap
+
+import (
+	"sync"
+	"time"
+)
+
+// coalescer buffers the latest UpdateCoalescing candidate until its window elapses.
+type coalescer[T any] struct {
+	mu      sync.Mutex
+	pending *T
+	armed   bool // a timer will install pending
+}
+
+// UpdateCoalescing is a debounced Update for noisy sources: newObj is buffered, and
+// when window has elapsed since the first buffered update of a burst, the most recent
+// candidate is installed (as by Update). Candidates superseded within the window are
+// released to the pool. Bursts thus cost one swap, and since the window starts with the
+// burst rather than being extended by every candidate, the latest value lands at most
+// window after the first, even under a continuous stream.
+//
+// The window is that of the call starting the burst. The install runs on a timer
+// goroutine; an Update in the meantime is overwritten when the window elapses.
+// A candidate still pending when the container is closed is released when its
+// window elapses.
+func (c *Container[T, PT]) UpdateCoalescing(newObj *T, window time.Duration) {
+	c.coalesce.mu.Lock()
+	prev := c.coalesce.pending
+	c.coalesce.pending = newObj
+	arm := !c.coalesce.armed
+	c.coalesce.armed = true
+	c.coalesce.mu.Unlock()
+
+	c.pool.Release(prev)
+	if arm {
+		time.AfterFunc(window, c.installCoalesced)
+	}
+}
+
+// installCoalesced installs the pending UpdateCoalescing candidate.
+func (c *Container[T, PT]) installCoalesced() {
+	c.coalesce.mu.Lock()
+	obj := c.coalesce.pending
+	c.coalesce.pending = nil
+	c.coalesce.armed = false
+	c.coalesce.mu.Unlock()
+
+	c.install(obj)
+}
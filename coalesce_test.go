@@ -0,0 +1,53 @@
+package poolswap_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestUpdateCoalescing(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	var swaps atomic.Int64
+	sub := container.SubscribeWeak(func() { swaps.Add(1) })
+	defer sub.Stop()
+
+	const n = 10
+	objs := make([]*MockPayload, n)
+	for i := range objs {
+		objs[i] = pool.Get()
+	}
+	_ = pool.StatsAndReset()
+	for _, obj := range objs {
+		container.UpdateCoalescing(obj, 50*time.Millisecond)
+	}
+	if container.Generation() != 0 {
+		t.Fatal("updates should be buffered until the window elapses")
+	}
+	if s := pool.Stats(); s.Puts != n-1 {
+		t.Fatalf("superseded candidates should be returned to the pool, got %+v", s)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for container.Generation() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the last candidate should be installed after the window")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(60 * time.Millisecond) // no further swaps may follow
+
+	current := container.Acquire()
+	defer container.Release(current)
+	if current != objs[n-1] {
+		t.Fatal("the most recent candidate should be installed")
+	}
+	if container.Generation() != 1 || swaps.Load() != 1 {
+		t.Fatalf("want exactly one swap, got generation %d, %d notifications",
+			container.Generation(), swaps.Load())
+	}
+}
@@ -0,0 +1,140 @@
+package poolswap
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ExternalContainer is a Container for types that do not embed Ref,
+// e.g. third-party structs you cannot modify.
+//
+// Reference counts are kept in an internal sync.Map keyed by object pointer.
+// This costs a map lookup on every Acquire and Release, and map insertion and
+// deletion on every GetNew and drain. In BenchmarkAcquireRelease an
+// Acquire/Release pair is roughly 1.5x slower than with Container, and GetNew
+// may allocate map nodes. Prefer Container whenever you can embed Ref.
+type ExternalContainer[T any] struct {
+	internal sync.Pool
+	refs     sync.Map // *T -> *externalRef[T]
+	mu       sync.RWMutex
+	current  *T
+	// Reset is called when refs hit 0.
+	// It should clear the object's state (e.g. clear maps, reset slices).
+	// Return true to put it back in the pool, false to discard (GC).
+	Reset func(*T) bool
+}
+
+type externalRef[T any] struct {
+	obj   *T
+	count atomic.Int64
+}
+
+// NewExternalContainer creates an empty container for objects of type T,
+// backed by its own pool.
+// factory allocates a new, empty T.
+// resetter prepares a used T for reuse (or returns false to discard it).
+func NewExternalContainer[T any](factory func() *T, resetter func(*T) bool) *ExternalContainer[T] {
+	return &ExternalContainer[T]{
+		internal: sync.Pool{
+			New: func() any { return &externalRef[T]{obj: factory(), count: atomic.Int64{}} },
+		},
+		refs:    sync.Map{},
+		mu:      sync.RWMutex{},
+		current: nil,
+		Reset:   resetter,
+	}
+}
+
+func (c *ExternalContainer[T]) lookup(obj *T) *externalRef[T] {
+	v, ok := c.refs.Load(obj)
+	if !ok {
+		return nil
+	}
+
+	return v.(*externalRef[T]) //nolint:forcetypeassert
+}
+
+// GetNew acquires a fresh object from the pool with Ref=1.
+func (c *ExternalContainer[T]) GetNew() *T {
+	r := c.internal.Get().(*externalRef[T]) //nolint:forcetypeassert
+	r.count.Store(1)
+	c.refs.Store(r.obj, r)
+
+	return r.obj
+}
+
+// Update the container to point at a new object.
+//
+// newObj should come from GetNew. Any other object is adopted: the container
+// takes ownership of it (reference count set to 1), and it is pooled once drained.
+// The old object will be returned to the pool once all existing readers release it.
+func (c *ExternalContainer[T]) Update(newObj *T) {
+	if newObj != nil && c.lookup(newObj) == nil {
+		r := &externalRef[T]{obj: newObj, count: atomic.Int64{}}
+		r.count.Store(1)
+		c.refs.Store(newObj, r)
+	}
+
+	c.mu.Lock()
+	oldObj := c.current
+	c.current = newObj
+	c.mu.Unlock()
+
+	c.Release(oldObj)
+}
+
+// Acquire returns the current active object with its reference count incremented.
+// The caller owns this reference and must call Release() when finished.
+//
+// Returns nil if the container is empty. If the current object is released more often
+// than acquired, it drains, and the container is empty until the next Update.
+func (c *ExternalContainer[T]) Acquire() *T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.current == nil {
+		return nil
+	}
+	r := c.lookup(c.current)
+	if r == nil {
+		return nil
+	}
+	r.count.Add(1)
+
+	return c.current
+}
+
+// Release decrements the ref count. If it hits 0, the object is returned to the pool.
+// Safe to call with nil. Releasing an object the container does not track (e.g. again
+// after it drained, or one from another container) is a no-op.
+func (c *ExternalContainer[T]) Release(obj *T) {
+	if obj == nil {
+		return
+	}
+	r := c.lookup(obj)
+	if r == nil {
+		return
+	}
+	if r.count.Add(-1) != 0 {
+		return
+	}
+	c.mu.Lock()
+	if c.current == obj { // over-released while installed
+		c.current = nil
+	}
+	c.mu.Unlock()
+	c.refs.Delete(obj)
+	if c.Reset(obj) {
+		c.internal.Put(r)
+	}
+}
+
+// WithAcquire is a helper that executes fn with the current object (can be nil) and
+// automatically releases it afterwards.
+func (c *ExternalContainer[T]) WithAcquire(fn func(obj *T)) {
+	obj := c.Acquire()
+	if obj != nil {
+		defer c.Release(obj)
+	}
+	fn(obj)
+}
@@ -0,0 +1,122 @@
+package poolswap_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+// Foreign stands in for a third-party type that cannot embed poolswap.Ref.
+type Foreign struct {
+	Version int
+	Data    map[string]string
+}
+
+func TestExternalContainer_HotSwap(t *testing.T) {
+	var resets atomic.Int64
+	container := poolswap.NewExternalContainer(
+		func() *Foreign { return &Foreign{Data: make(map[string]string)} },
+		func(f *Foreign) bool {
+			resets.Add(1)
+			f.Version = 0
+			clear(f.Data)
+			return true
+		},
+	)
+
+	if container.Acquire() != nil {
+		t.Fatal("new container should be empty")
+	}
+
+	v1 := container.GetNew()
+	v1.Version = 1
+	v1.Data["k"] = "v1"
+	container.Update(v1)
+
+	held := container.Acquire()
+	if held != v1 {
+		t.Fatal("Acquire should return the current object")
+	}
+
+	v2 := container.GetNew()
+	v2.Version = 2
+	v2.Data["k"] = "v2"
+	container.Update(v2)
+
+	if resets.Load() != 0 {
+		t.Fatal("old object must not be reset while a reader holds it")
+	}
+	if held.Version != 1 || held.Data["k"] != "v1" {
+		t.Fatalf("held object changed under the reader: %+v", held)
+	}
+
+	container.Release(held)
+	if resets.Load() != 1 {
+		t.Fatalf("old object should be reset once drained, resets=%d", resets.Load())
+	}
+
+	container.WithAcquire(func(f *Foreign) {
+		if f != v2 || f.Data["k"] != "v2" {
+			t.Fatalf("want v2, got %+v", f)
+		}
+	})
+}
+
+func TestExternalContainer_AdoptsForeignObject(t *testing.T) {
+	var resets atomic.Int64
+	container := poolswap.NewExternalContainer(
+		func() *Foreign { return &Foreign{} },
+		func(_ *Foreign) bool { resets.Add(1); return true },
+	)
+
+	adopted := &Foreign{Version: 7}
+	container.Update(adopted)
+	container.Update(container.GetNew())
+
+	if resets.Load() != 1 {
+		t.Fatalf("adopted object should be reset once displaced and drained, resets=%d", resets.Load())
+	}
+}
+
+func TestExternalContainer_DoubleRelease(t *testing.T) {
+	var resets atomic.Int64
+	container := poolswap.NewExternalContainer(
+		func() *Foreign { return &Foreign{} },
+		func(_ *Foreign) bool { resets.Add(1); return true },
+	)
+	container.Update(container.GetNew())
+
+	old := container.Acquire()
+	container.Update(container.GetNew())
+	container.Release(old) // drains old
+	container.Release(old) // must not panic
+	container.Release(&Foreign{})
+
+	if resets.Load() != 1 {
+		t.Fatalf("the drained object should be reset exactly once, resets=%d", resets.Load())
+	}
+}
+
+func TestExternalContainer_OverReleasedCurrent(t *testing.T) {
+	container := poolswap.NewExternalContainer(
+		func() *Foreign { return &Foreign{} },
+		func(_ *Foreign) bool { return true },
+	)
+	container.Update(container.GetNew())
+
+	r := container.Acquire()
+	container.Release(r)
+	container.Release(r) // drains the current object
+	if got := container.Acquire(); got != nil {
+		t.Fatal("Acquire after the current object drained should act as if the container were empty")
+	}
+
+	next := container.GetNew()
+	container.Update(next)
+	got := container.Acquire()
+	if got != next {
+		t.Fatal("Update should install a new object again")
+	}
+	container.Release(got)
+}
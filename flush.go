@@ -0,0 +1,45 @@
+package poolswap
+
+// WithOnRetireSync runs fn on every object the pool takes back (drained, or fed via Put)
+// before it is reset, e.g. to persist writes the object has buffered. fn runs
+// synchronously on the drain path, i.e. in the goroutine dropping the last reference.
+// If fn returns an error, the object is discarded instead of pooled, so that an object
+// whose flush failed is never reused; fn is expected to report the error itself.
+//
+// fn also runs for objects that are discarded anyway (e.g. after Recycle), since their
+// buffered state still has to be persisted.
+//
+// T must be the pool's object type; NewPool panics otherwise.
+// Pool.SetOnRetireSync installs the same hook with the type checked at compile time.
+func WithOnRetireSync[T any](fn func(obj *T) error) PoolOption {
+	return func(o *poolOptions) {
+		o.onRetireSync = fn
+	}
+}
+
+// SetOnRetireSync replaces the pool's WithOnRetireSync hook; a nil fn removes it.
+// Safe for concurrent use with drains: each drain runs either the old or the new hook.
+//
+// Child pools created by NewChildPool keep the hook they were created with.
+func (p *Pool[T, PT]) SetOnRetireSync(fn func(obj *T) error) {
+	if fn == nil {
+		p.onRetire.Store(nil)
+
+		return
+	}
+	p.onRetire.Store(&fn)
+}
+
+// flushed runs the WithOnRetireSync hook on obj, reporting whether it may be pooled.
+// With WithNoPanic, a panicking hook counts as a failed flush.
+func (p *Pool[T, PT]) flushed(obj *T) bool {
+	fn := p.onRetire.Load()
+	if fn == nil {
+		return true
+	}
+	if p.onViolation != nil {
+		defer p.recoverCallback()
+	}
+
+	return (*fn)(obj) == nil
+}
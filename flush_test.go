@@ -0,0 +1,86 @@
+package poolswap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+var errFlush = errors.New("flush failed")
+
+func TestWithOnRetireSync(t *testing.T) {
+	var flushed []int64
+	pool := poolswap.NewPool(
+		func() *MockPayload { return new(MockPayload) },
+		func(obj *MockPayload) bool {
+			obj.Recycled.Store(true)
+
+			return true
+		},
+		poolswap.WithOnRetireSync(func(obj *MockPayload) error {
+			if obj.Recycled.Load() {
+				t.Error("flush must run before Reset")
+			}
+			flushed = append(flushed, obj.ID)
+			if obj.ID < 0 {
+				return errFlush
+			}
+
+			return nil
+		}),
+		poolswap.WithMaxIdle(4),
+	)
+
+	good, bad := pool.Get(), pool.Get()
+	good.ID, bad.ID = 1, -1
+	container := poolswap.NewContainer(pool, good)
+	container.Update(bad)
+	container.Update(nil)
+
+	if len(flushed) != 2 || flushed[0] != 1 || flushed[1] != -1 {
+		t.Fatalf("both retired objects should be flushed in order, got %v", flushed)
+	}
+	if !good.Recycled.Load() || bad.Recycled.Load() {
+		t.Fatal("only the successfully flushed object should be reset")
+	}
+	if s := pool.Stats(); s.Puts != 1 || s.Discards != 1 {
+		t.Fatalf("want 1 pooled and 1 discarded object, got %+v", s)
+	}
+	if got := pool.Get(); got != good {
+		t.Fatal("the successfully flushed object should be reused")
+	}
+}
+
+func TestWithOnRetireSync_TypeMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewPool should reject a hook for another type")
+		}
+	}()
+	poolswap.NewPool(
+		func() *MockPayload { return new(MockPayload) },
+		func(*MockPayload) bool { return true },
+		poolswap.WithOnRetireSync(func(*poolswap.Typed[int]) error { return nil }),
+	)
+}
+
+func TestPool_SetOnRetireSync(t *testing.T) {
+	pool := newBoundedPool(poolswap.WithMaxIdle(4))
+	var flushed int
+	pool.SetOnRetireSync(func(*MockPayload) error {
+		flushed++
+
+		return errFlush
+	})
+	pool.Release(pool.Get())
+	if s := pool.Stats(); flushed != 1 || s.Discards != 1 {
+		t.Fatalf("the hook should run on the drain and discard the object, got %d, %+v", flushed, s)
+	}
+
+	pool.SetOnRetireSync(nil)
+	pool.Release(pool.Get())
+	if s := pool.Stats(); flushed != 1 || s.Puts != 1 {
+		t.Fatalf("a nil hook should remove it, got %d, %+v", flushed, s)
+	}
+}
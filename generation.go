@@ -0,0 +1,139 @@
+package poolswap
+
+import "sync"
+
+// Generations number the objects installed in a Container: the initial state
+// (empty, or the object passed to NewContainer) is generation 0, and every
+// Update installs the next generation. They let writers and readers correlate
+// which object ("config gen=N") they are working with.
+
+// UpdateG is Update, returning the generation assigned to newObj
+// (or 0 if the container is closed and newObj was rejected).
+func (c *Container[T, PT]) UpdateG(newObj *T) uint64 {
+	gen, ok := c.install(newObj)
+	if !ok {
+		return 0
+	}
+
+	return gen
+}
+
+// UpdateWithResult is Update, returning the generation that was current before the swap
+// and the one assigned to newObj, for logging transitions ("gen 7 -> 8"). Both come from
+// the same swap, so concurrent Updates cannot interleave between them: oldGen is always
+// newGen-1. Returns 0, 0 if the container is closed and newObj was rejected.
+func (c *Container[T, PT]) UpdateWithResult(newObj *T) (uint64, uint64) {
+	newGen := c.UpdateG(newObj)
+	if newGen == 0 {
+		return 0, 0
+	}
+
+	return newGen - 1, newGen
+}
+
+// install makes obj the current object under the next generation, retires the
+// displaced object and notifies subscribers. If the container is closed, obj is
+// released instead and install reports false.
+func (c *Container[T, PT]) install(obj *T) (uint64, bool) {
+	return c.installIf(obj, nil)
+}
+
+// installIf is install, but only if accept (if non-nil) approves of the current object
+// (nil if empty) under the write lock; otherwise obj is released and installIf reports false.
+func (c *Container[T, PT]) installIf(obj *T, accept func(cur *T) bool) (uint64, bool) {
+	oldObj, gen, ok := c.swap(obj, accept)
+	if !ok {
+		return 0, false
+	}
+	if oldObj != nil {
+		c.retire(oldObj)
+	}
+	c.notify()
+
+	return gen, true
+}
+
+// swap is the locked part of installIf: it makes obj current and returns the displaced
+// object along with the container's reference to it, which the caller must retire.
+func (c *Container[T, PT]) swap(obj *T, accept func(cur *T) bool) (*T, uint64, bool) {
+	c.pool.adopt(obj)
+	c.mu.Lock()
+	if c.status != StatusActive || (accept != nil && !accept(c.current)) {
+		c.mu.Unlock()
+		c.pool.Release(obj)
+
+		return nil, 0, false
+	}
+	oldObj := c.current
+	evicted := c.recordLocked(oldObj, c.gen)
+	c.current = obj
+	c.peak.Store(0)
+	c.gen++
+	gen := c.gen
+	c.mu.Unlock()
+
+	if evicted != nil {
+		c.retire(evicted)
+	}
+
+	return oldObj, gen, true
+}
+
+// UpdateWithPrevious is Update, also returning the displaced object with a reference held
+// for the caller, e.g. to diff the old and new configuration. The previous object stays
+// intact (not reset or reused) until release is called, regardless of other readers;
+// it then drains as usual. Calling release more than once is a no-op.
+//
+// Returns nil (and a no-op release) if the container was empty, or is closed and
+// newObj was rejected.
+func (c *Container[T, PT]) UpdateWithPrevious(newObj *T) (*T, func()) {
+	prev, _, ok := c.swap(newObj, nil)
+	if !ok {
+		return nil, func() {}
+	}
+	if prev == nil {
+		c.notify()
+
+		return nil, func() {}
+	}
+	PT(prev).addRef(1) // the caller's, before the container's is dropped
+	c.retire(prev)
+	c.notify()
+
+	var once sync.Once
+
+	return prev, func() { once.Do(func() { c.Release(prev) }) }
+}
+
+// AcquireWithGeneration is Acquire, also returning the generation of the acquired object.
+// The object and generation are read together, so they always match.
+//
+// Reads are monotonic: Updates and Acquires are serialized by the container's lock,
+// so once a goroutine has observed generation N, its later Acquires never return an
+// older object (until Reset deliberately restarts the generations). Goroutines that
+// hand generations to each other get the same guarantee, as long as the hand-off
+// itself synchronizes (e.g. via a channel).
+//
+// Returns nil (and the current generation) if the container is empty.
+func (c *Container[T, PT]) AcquireWithGeneration() (*T, uint64) {
+	c.rlock()
+	obj := c.current
+	gen := c.gen
+	var refs int64
+	if obj != nil {
+		refs = obj.addRef(1)
+		c.notePeak(refs)
+	}
+	c.mu.RUnlock()
+	c.checkMaxRefs(refs)
+
+	return obj, gen
+}
+
+// Generation returns the generation of the current object.
+func (c *Container[T, PT]) Generation() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.gen
+}
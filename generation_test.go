@@ -0,0 +1,214 @@
+package poolswap_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestUpdateG_StrictlyIncreasing(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	if got := container.Generation(); got != 0 {
+		t.Fatalf("initial generation should be 0, got %d", got)
+	}
+
+	var last uint64
+	for range 10 {
+		obj := pool.Get()
+		gen := container.UpdateG(obj)
+		if gen <= last {
+			t.Fatalf("generation did not increase: %d after %d", gen, last)
+		}
+		last = gen
+
+		got, acquiredGen := container.AcquireWithGeneration()
+		if got != obj || acquiredGen != gen {
+			t.Fatalf("AcquireWithGeneration: want (%p, %d), got (%p, %d)", obj, gen, got, acquiredGen)
+		}
+		container.Release(got)
+	}
+
+	container.Update(pool.Get())
+	if got := container.Generation(); got != last+1 {
+		t.Fatalf("Update should advance the generation too: want %d, got %d", last+1, got)
+	}
+}
+
+func TestUpdateWithResult(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	var prevNew uint64
+	for range 5 {
+		oldGen, newGen := container.UpdateWithResult(pool.Get())
+		if oldGen != prevNew || newGen != oldGen+1 {
+			t.Fatalf("want %d -> %d, got %d -> %d", prevNew, prevNew+1, oldGen, newGen)
+		}
+		if container.Generation() != newGen {
+			t.Fatalf("newGen %d should be current, got %d", newGen, container.Generation())
+		}
+		prevNew = newGen
+	}
+
+	container.Close()
+	if oldGen, newGen := container.UpdateWithResult(pool.Get()); oldGen != 0 || newGen != 0 {
+		t.Fatalf("a closed container should report 0 -> 0, got %d -> %d", oldGen, newGen)
+	}
+}
+
+func TestUpdateWithPrevious(t *testing.T) {
+	pool := newMockPool()
+	old := pool.Get()
+	old.Content = append(old.Content, "v1"...)
+	old.Recycled.Store(false)
+	container := poolswap.NewContainer(pool, old)
+
+	reader := container.Acquire()
+	next := pool.Get()
+	next.Content = append(next.Content, "v2"...)
+	prev, release := container.UpdateWithPrevious(next)
+	if prev != old || string(prev.Content) != "v1" {
+		t.Fatalf("want the pre-swap object, got %q", prev.Content)
+	}
+
+	container.Release(reader)
+	if old.Recycled.Load() || string(prev.Content) != "v1" {
+		t.Fatal("prev must stay intact while the caller holds it, whatever other readers do")
+	}
+	if container.RetiredCount() != 1 {
+		t.Fatalf("prev should be tracked as retired, got %d", container.RetiredCount())
+	}
+
+	release()
+	release()
+	if !old.Recycled.Load() || old.DebugPeekRef() != 0 {
+		t.Fatalf("prev should be pooled after release, Ref=%d", old.DebugPeekRef())
+	}
+
+	empty := poolswap.NewEmptyContainer(pool)
+	prev, release = empty.UpdateWithPrevious(pool.Get())
+	release()
+	if prev != nil {
+		t.Fatal("an empty container has no previous object")
+	}
+}
+
+func TestReset(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	for range 3 {
+		container.Update(pool.Get())
+	}
+	held := container.Acquire()
+	held.Recycled.Store(false)
+
+	initial := &MockPayload{ID: 99}
+	container.Reset(initial)
+
+	obj, gen := container.AcquireWithGeneration()
+	if obj != initial || gen != 0 {
+		t.Fatalf("after Reset want initial at gen 0, got %p at gen %d", obj, gen)
+	}
+	container.Release(obj)
+	if initial.DebugPeekRef() != 1 {
+		t.Fatalf("container should own the initial object with Ref=1, got %d", initial.DebugPeekRef())
+	}
+
+	if held.Recycled.Load() {
+		t.Fatal("outstanding reference must stay valid after Reset")
+	}
+	container.Release(held)
+	if !held.Recycled.Load() || container.RetiredCount() != 0 {
+		t.Fatal("displaced object should be returned to the pool once released")
+	}
+
+	if gen := container.UpdateG(pool.Get()); gen != 1 {
+		t.Fatalf("generations should restart after Reset, got %d", gen)
+	}
+}
+
+func TestAcquireWithGeneration_Monotonic(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() {
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					container.Update(pool.Get())
+				}
+			}
+		})
+	}
+
+	var readers sync.WaitGroup
+	for range 4 {
+		readers.Go(func() {
+			var last uint64
+			for range 20_000 {
+				obj, gen := container.AcquireWithGeneration()
+				if gen < last {
+					t.Errorf("generation regressed from %d to %d", last, gen)
+					container.Release(obj)
+					return
+				}
+				last = gen
+				container.Release(obj)
+			}
+		})
+	}
+	readers.Wait()
+	close(done)
+	wg.Wait()
+}
+
+// TestUpdate_VisibleOnReturn checks the guarantee documented on Update: an Acquire
+// that starts after an Update returned (as learned through an atomic) never gets an
+// older object. Run with -race to also check that writes to the new object made before
+// Update are visible to readers.
+func TestUpdate_VisibleOnReturn(t *testing.T) {
+	pool := newMockPool()
+	initial := pool.Get()
+	initial.ID = 0
+	container := poolswap.NewContainer(pool, initial)
+
+	var published atomic.Uint64
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for range 8 {
+		wg.Go(func() {
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				want := published.Load()
+				obj, gen := container.AcquireWithGeneration()
+				if gen < want || uint64(obj.ID) != gen {
+					t.Errorf("saw generation %d (ID %d) after generation %d was published", gen, obj.ID, want)
+				}
+				container.Release(obj)
+			}
+		})
+	}
+
+	for i := range uint64(2000) {
+		obj := pool.Get()
+		obj.ID = int64(i + 1) // a plain write before Update, read by the readers
+		gen := container.UpdateG(obj)
+		published.Store(gen)
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,70 @@
+package poolswap
+
+import "sync"
+
+// StatsSource is anything that reports pool counters; every *Pool implements it.
+type StatsSource interface {
+	Stats() Stats
+}
+
+// PoolGroup aggregates the Stats of several pools (of any element types) into one view.
+// Pools may be added and removed concurrently with Stats.
+type PoolGroup struct {
+	mu    sync.RWMutex
+	pools map[string]StatsSource
+}
+
+// GroupStats is a snapshot of a PoolGroup: the summed counters and the per-pool breakdown.
+type GroupStats struct {
+	// Total is the sum of the member pools' counters.
+	Total Stats `json:"total"`
+	// Pools holds each member's counters by the name it was added under.
+	Pools map[string]Stats `json:"pools"`
+}
+
+// NewPoolGroup creates an empty PoolGroup.
+func NewPoolGroup() *PoolGroup {
+	return &PoolGroup{
+		mu:    sync.RWMutex{},
+		pools: make(map[string]StatsSource),
+	}
+}
+
+// Add registers pool under name, replacing any pool previously added under the same name.
+func (g *PoolGroup) Add(name string, pool StatsSource) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pools[name] = pool
+}
+
+// Remove unregisters the pool added under name. It is a no-op for unknown names.
+func (g *PoolGroup) Remove(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.pools, name)
+}
+
+// Stats returns the current counters of every member pool and their sum.
+//
+// Total is computed from the same per-pool snapshots that are returned in Pools,
+// so the two are always consistent with each other (though, as with Pool.Stats,
+// not an atomic snapshot of concurrently running operations).
+func (g *PoolGroup) Stats() GroupStats {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	out := GroupStats{
+		Total: Stats{Gets: 0, News: 0, Puts: 0, Discards: 0},
+		Pools: make(map[string]Stats, len(g.pools)),
+	}
+	for name, pool := range g.pools {
+		s := pool.Stats()
+		out.Pools[name] = s
+		out.Total.Gets += s.Gets
+		out.Total.News += s.News
+		out.Total.Puts += s.Puts
+		out.Total.Discards += s.Discards
+	}
+
+	return out
+}
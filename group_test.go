@@ -0,0 +1,63 @@
+package poolswap_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestPoolGroup_Rollup(t *testing.T) {
+	payloads := newMockPool()
+	buffers := poolswap.NewTypedPool(
+		func() []byte { return make([]byte, 0, 64) },
+		func(*[]byte) bool { return false },
+	)
+
+	group := poolswap.NewPoolGroup()
+	group.Add("payloads", payloads)
+	group.Add("buffers", buffers)
+
+	a, b := payloads.Get(), payloads.Get()
+	payloads.Release(a)
+	payloads.Release(b)
+	buffers.Release(buffers.Get()) // discarded
+
+	got := group.Stats()
+	if got.Pools["payloads"] != payloads.Stats() || got.Pools["buffers"] != buffers.Stats() {
+		t.Fatalf("per-pool breakdown mismatch: %+v", got.Pools)
+	}
+	want := poolswap.Stats{Gets: 3, News: 3, Puts: 2, Discards: 1}
+	if got.Total != want {
+		t.Fatalf("want total %+v, got %+v", want, got.Total)
+	}
+
+	group.Remove("buffers")
+	got = group.Stats()
+	if _, ok := got.Pools["buffers"]; ok || got.Total != payloads.Stats() {
+		t.Fatalf("removed pool should not be counted: %+v", got)
+	}
+}
+
+func TestPoolGroup_Concurrent(t *testing.T) {
+	group := poolswap.NewPoolGroup()
+	pool := newMockPool()
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Go(func() {
+			name := string(rune('a' + i))
+			for range 100 {
+				group.Add(name, pool)
+				pool.Release(pool.Get())
+				_ = group.Stats()
+				group.Remove(name)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := group.Stats(); len(got.Pools) != 0 {
+		t.Fatalf("all pools were removed, got %+v", got.Pools)
+	}
+}
@@ -0,0 +1,138 @@
+package poolswap
+
+import (
+	"sync"
+	"time"
+)
+
+// Guard holds a reference acquired from a Container and releases it on Release.
+// The zero Guard holds nothing.
+//
+// A Guard must not be copied while it holds a reference: each copy would release it.
+type Guard[T any, PT PtrRef[T]] struct {
+	c   *Container[T, PT]
+	obj *T
+}
+
+// Value returns the guarded object (nil if the container was empty or the guard was released).
+func (g *Guard[T, PT]) Value() *T {
+	return g.obj
+}
+
+// Release releases the guarded reference. Repeated calls are no-ops.
+func (g *Guard[T, PT]) Release() {
+	if g.obj == nil {
+		return
+	}
+	g.c.Release(g.obj)
+	g.obj = nil
+}
+
+// AcquireGuard is Acquire, returning the reference wrapped in a Guard:
+//
+//	g := c.AcquireGuard()
+//	defer g.Release()
+//	obj := g.Value()
+func (c *Container[T, PT]) AcquireGuard() Guard[T, PT] {
+	return Guard[T, PT]{c: c, obj: c.Acquire()}
+}
+
+// Borrowed is the current object of a Container, borrowed for the duration of a call
+// (see Container.Current). It is a Guard under borrowing names: Value and Done.
+//
+// A Borrowed must not be copied while it holds a reference: each copy would release it.
+type Borrowed[T any, PT PtrRef[T]] struct {
+	g Guard[T, PT]
+}
+
+// Value returns the borrowed object (nil if the container was empty or Done was called).
+func (b *Borrowed[T, PT]) Value() *T {
+	return b.g.Value()
+}
+
+// Done ends the borrow, releasing the reference. Repeated calls are no-ops.
+func (b *Borrowed[T, PT]) Done() {
+	b.g.Release()
+}
+
+// Current borrows the current object. It is AcquireGuard with naming that states
+// the intent, for APIs that use the object for the duration of one call:
+//
+//	cur := c.Current()
+//	defer cur.Done()
+//	cfg := cur.Value()
+//
+// Current does not allocate.
+func (c *Container[T, PT]) Current() Borrowed[T, PT] {
+	return Borrowed[T, PT]{g: c.AcquireGuard()}
+}
+
+// AcquireInto is Acquire, recording the reference in the caller-provided guard g
+// (which should not hold a reference already) and returning the object.
+// With g on the caller's stack, this never allocates, even in the hottest loops:
+//
+//	var g poolswap.Guard[MyCache, *MyCache]
+//	obj := c.AcquireInto(&g)
+//	defer g.Release()
+func (c *Container[T, PT]) AcquireInto(g *Guard[T, PT]) *T {
+	obj := c.Acquire()
+	g.c = c
+	g.obj = obj
+
+	return obj
+}
+
+// AcquireWithCleanup is Acquire, returning a release function that first calls cleanup
+// with the object and then releases this reference. It lets a reader tear down side state
+// it attached to its borrow, independently of other readers and of the object draining.
+// Calling release more than once is a no-op, so cleanup runs exactly once per acquire.
+//
+// Returns nil (and a no-op release, without calling cleanup) if the container is empty.
+func (c *Container[T, PT]) AcquireWithCleanup(cleanup func(obj *T)) (*T, func()) {
+	obj := c.Acquire()
+	if obj == nil {
+		return nil, func() {}
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			cleanup(obj)
+			c.Release(obj)
+		})
+	}
+
+	return obj, release
+}
+
+// Freeze acquires the current object and guarantees it will not be reset or reused
+// for at least d, even if the returned release function is called earlier; e.g. when
+// handing the pointer to a short-lived async task. The reference is dropped when both
+// release has been called and d has elapsed, whichever is later.
+// Calling release more than once is a no-op; not calling it leaks the reference, as with Acquire.
+//
+// A frozen object stays in memory for the whole window even if it has been replaced,
+// so long windows on frequently updated containers keep several generations alive.
+//
+// Returns nil (and a no-op release) if the container is empty.
+func (c *Container[T, PT]) Freeze(d time.Duration) (*T, func()) {
+	obj := c.Acquire()
+	if obj == nil {
+		return nil, func() {}
+	}
+	deadline := time.Now().Add(d)
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			if remaining := time.Until(deadline); remaining > 0 {
+				time.AfterFunc(remaining, func() { c.Release(obj) })
+
+				return
+			}
+			c.Release(obj)
+		})
+	}
+
+	return obj, release
+}
@@ -0,0 +1,167 @@
+package poolswap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestGuard(t *testing.T) {
+	pool := newMockPool()
+	obj := pool.Get()
+	container := poolswap.NewContainer(pool, obj)
+
+	g := container.AcquireGuard()
+	if g.Value() != obj || obj.DebugPeekRef() != 2 {
+		t.Fatalf("guard should hold a reference to the current object, Ref=%d", obj.DebugPeekRef())
+	}
+	g.Release()
+	g.Release()
+	if obj.DebugPeekRef() != 1 || g.Value() != nil {
+		t.Fatalf("Release should drop the reference exactly once, Ref=%d", obj.DebugPeekRef())
+	}
+
+	var into poolswap.Guard[MockPayload, *MockPayload]
+	into.Release() // zero guard holds nothing
+	if got := container.AcquireInto(&into); got != obj || obj.DebugPeekRef() != 2 {
+		t.Fatalf("AcquireInto should return the current object with a reference held, Ref=%d", obj.DebugPeekRef())
+	}
+	into.Release()
+	if obj.DebugPeekRef() != 1 {
+		t.Fatalf("Release should drop the AcquireInto reference, Ref=%d", obj.DebugPeekRef())
+	}
+
+	empty := poolswap.NewEmptyContainer(pool)
+	if empty.AcquireInto(&into) != nil {
+		t.Fatal("AcquireInto on an empty container should return nil")
+	}
+	into.Release()
+}
+
+func TestAcquireInto_NoAllocs(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		var g poolswap.Guard[MockPayload, *MockPayload]
+		_ = container.AcquireInto(&g)
+		g.Release()
+	})
+	if allocs != 0 {
+		t.Fatalf("AcquireInto with a stack guard should not allocate, got %v allocs/op", allocs)
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	pool := newMockPool()
+	obj := pool.Get()
+	container := poolswap.NewContainer(pool, obj)
+
+	cur := container.Current()
+	if cur.Value() != obj || obj.DebugPeekRef() != 2 {
+		t.Fatalf("Current should borrow the current object, Ref=%d", obj.DebugPeekRef())
+	}
+	cur.Done()
+	cur.Done()
+	if cur.Value() != nil || obj.DebugPeekRef() != 1 {
+		t.Fatalf("Done should release exactly once, Ref=%d", obj.DebugPeekRef())
+	}
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		cur := container.Current()
+		_ = cur.Value()
+		cur.Done()
+	})
+	if allocs != 0 {
+		t.Fatalf("Current should not allocate, got %v allocs/op", allocs)
+	}
+}
+
+func TestAcquireWithCleanup(t *testing.T) {
+	pool := newMockPool()
+	obj := pool.Get()
+	container := poolswap.NewContainer(pool, obj)
+
+	var cleanups []string
+	_, releaseA := container.AcquireWithCleanup(func(got *MockPayload) {
+		if got != obj || got.DebugPeekRef() != 3 {
+			t.Errorf("cleanup should run on the object before its reference is dropped, Ref=%d", got.DebugPeekRef())
+		}
+		cleanups = append(cleanups, "a")
+	})
+	_, releaseB := container.AcquireWithCleanup(func(*MockPayload) { cleanups = append(cleanups, "b") })
+	other := container.Acquire()
+
+	container.Release(other)
+	if len(cleanups) != 0 {
+		t.Fatalf("other readers' releases must not run cleanups, got %v", cleanups)
+	}
+	releaseA()
+	releaseA()
+	if len(cleanups) != 1 || cleanups[0] != "a" || obj.DebugPeekRef() != 2 {
+		t.Fatalf("release should run its own cleanup exactly once, got %v, Ref=%d", cleanups, obj.DebugPeekRef())
+	}
+	releaseB()
+	if len(cleanups) != 2 || obj.DebugPeekRef() != 1 {
+		t.Fatalf("want both cleanups and only the container's reference left, got %v, Ref=%d",
+			cleanups, obj.DebugPeekRef())
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	pool := newMockPool()
+	obj := pool.Get()
+	obj.Recycled.Store(false)
+	container := poolswap.NewContainer(pool, obj)
+
+	const window = 100 * time.Millisecond
+	start := time.Now()
+	frozen, release := container.Freeze(window)
+	if frozen != obj {
+		t.Fatal("Freeze should return the current object")
+	}
+	release() // early release
+	release() // no-op
+	container.Update(pool.Get())
+
+	time.Sleep(window / 4)
+	if obj.Recycled.Load() {
+		t.Fatal("frozen object was reset before the freeze window ended")
+	}
+
+	for !obj.Recycled.Load() {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("frozen object was not released after the freeze window")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if elapsed := time.Since(start); elapsed < window {
+		t.Fatalf("object released after %v, before the %v window", elapsed, window)
+	}
+
+	empty := poolswap.NewEmptyContainer(pool)
+	if got, release := empty.Freeze(window); got != nil {
+		t.Fatal("Freeze on an empty container should return nil")
+	} else {
+		release()
+	}
+}
+
+func TestFreeze_LateRelease(t *testing.T) {
+	pool := newMockPool()
+	obj := pool.Get()
+	obj.Recycled.Store(false)
+	container := poolswap.NewContainer(pool, obj)
+
+	_, release := container.Freeze(time.Millisecond)
+	container.Update(pool.Get())
+	time.Sleep(10 * time.Millisecond)
+	if obj.Recycled.Load() {
+		t.Fatal("frozen object must stay referenced until release is called")
+	}
+	release()
+	if !obj.Recycled.Load() {
+		t.Fatal("release after the window should drop the reference immediately")
+	}
+}
@@ -0,0 +1,56 @@
+package poolswap
+
+import "net/http"
+
+// HandlerContainer holds an http.Handler (e.g. a router) that can be swapped atomically
+// while serving: each request acquires the current handler and keeps using it until it
+// completes, even if a new one is installed in the meantime.
+//
+// Handlers are immutable, so nothing is reset or reused: displaced handlers are
+// dropped once their last request is done.
+type HandlerContainer struct {
+	c *Container[Typed[http.Handler], *Typed[http.Handler]]
+}
+
+// NewHandlerContainer creates a HandlerContainer serving h.
+func NewHandlerContainer(h http.Handler) *HandlerContainer {
+	pool := NewTypedPool[http.Handler](nil, func(*http.Handler) bool { return false })
+
+	return &HandlerContainer{c: NewContainer(pool, wrapHandler(pool, h))}
+}
+
+// wrapHandler wraps h in an object from pool, or returns nil (empty) for a nil h.
+func wrapHandler(pool *Pool[Typed[http.Handler], *Typed[http.Handler]], h http.Handler) *Typed[http.Handler] {
+	if h == nil {
+		return nil
+	}
+	t := pool.Get()
+	t.Value = h
+
+	return t
+}
+
+// Swap installs h for requests starting from now on. Requests in flight finish
+// with the handler they started with. A nil h makes the container answer
+// 503 Service Unavailable until the next Swap.
+func (hc *HandlerContainer) Swap(h http.Handler) {
+	hc.c.Update(wrapHandler(hc.c.Pool(), h))
+}
+
+// Container returns the underlying container, e.g. for its diagnostics (State, Generation).
+func (hc *HandlerContainer) Container() *Container[Typed[http.Handler], *Typed[http.Handler]] {
+	return hc.c
+}
+
+// ServeHTTP serves the request with the current handler.
+func (hc *HandlerContainer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h := hc.c.Acquire()
+	if h == nil {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+		return
+	}
+	defer hc.c.Release(h)
+
+	h.Value.ServeHTTP(w, r)
+}
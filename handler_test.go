@@ -0,0 +1,63 @@
+package poolswap_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestHandlerContainer(t *testing.T) {
+	entered := make(chan struct{})
+	proceed := make(chan struct{})
+	v1 := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(entered)
+		<-proceed
+		_, _ = io.WriteString(w, "v1")
+	})
+	v2 := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "v2")
+	})
+
+	hc := poolswap.NewHandlerContainer(v1)
+	srv := httptest.NewServer(hc)
+	defer srv.Close()
+
+	get := func() string {
+		resp, err := http.Get(srv.URL) //nolint:noctx // test against a local server
+		if err != nil {
+			t.Error(err)
+
+			return ""
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+
+		return string(body)
+	}
+
+	inFlight := make(chan string)
+	go func() { inFlight <- get() }()
+	<-entered
+
+	hc.Swap(v2)
+	if got := get(); got != "v2" {
+		t.Fatalf("new requests should use the new handler, got %q", got)
+	}
+	close(proceed)
+	if got := <-inFlight; got != "v1" {
+		t.Fatalf("the in-flight request should complete with its handler, got %q", got)
+	}
+
+	hc.Swap(nil)
+	rec := httptest.NewRecorder()
+	hc.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("an empty container should answer 503, got %d", rec.Code)
+	}
+	if s := hc.Container().State(); s.Retired != 0 {
+		t.Fatalf("displaced handlers should be dropped once idle, got %+v", s)
+	}
+}
@@ -0,0 +1,96 @@
+package poolswap
+
+import "slices"
+
+// WithHistory makes the container keep the last n displaced objects, holding a reference
+// to each so they are not reset or reused, for inspection via History (e.g. to see what
+// changed before a bad object was installed). Once more than n have been displaced, the
+// oldest is released and drains as usual. Debuggability is bought with memory: up to n
+// more objects stay alive, and RecycleInto cannot reuse an object while it is in the
+// history. Objects in the history are not counted by RetiredCount or ForEachRetired;
+// one evicted while readers still hold it is. Reset and Close release the history;
+// Detach does not add to it.
+func WithHistory(n int) ContainerOption {
+	return func(o *containerOptions) {
+		o.history = max(n, 0)
+	}
+}
+
+// history is the ring of objects kept by WithHistory, oldest first; guarded by Container.mu.
+// Objects in it are not counted as retired: the history's reference is not a reader's.
+type history[T any] struct {
+	max     int
+	entries []historyEntry[T]
+}
+
+type historyEntry[T any] struct {
+	obj *T
+	gen uint64
+}
+
+// History returns the objects kept by WithHistory, oldest first, each with a reference
+// acquired for the caller: treat them as read-only, and release them (e.g. with ReleaseAll)
+// when done. Returns nil without WithHistory or before the first object was displaced.
+func (c *Container[T, PT]) History() []*T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.history.entries) == 0 {
+		return nil
+	}
+	out := make([]*T, 0, len(c.history.entries))
+	for _, e := range c.history.entries {
+		PT(e.obj).addRef(1)
+		out = append(out, e.obj)
+	}
+
+	return out
+}
+
+// recordLocked adds obj, displaced as generation gen, to the history with a reference of
+// its own. It returns the entry evicted to make room, to be retired once c.mu is unlocked,
+// or nil. c.mu must be held, and obj must still be referenced by the container.
+func (c *Container[T, PT]) recordLocked(obj *T, gen uint64) *T {
+	if c.history.max == 0 || obj == nil {
+		return nil
+	}
+	PT(obj).addRef(1)
+
+	var evicted *T
+	if len(c.history.entries) == c.history.max {
+		evicted = c.history.entries[0].obj
+		n := copy(c.history.entries, c.history.entries[1:])
+		c.history.entries[n] = historyEntry[T]{obj: nil, gen: 0}
+		c.history.entries = c.history.entries[:n]
+	}
+	c.history.entries = append(c.history.entries, historyEntry[T]{obj: obj, gen: gen})
+
+	return evicted
+}
+
+// clearHistoryLocked empties the history, returning its objects to be retired
+// once c.mu is unlocked. c.mu must be held.
+func (c *Container[T, PT]) clearHistoryLocked() []*T {
+	if len(c.history.entries) == 0 {
+		return nil
+	}
+	objs := make([]*T, 0, len(c.history.entries))
+	for _, e := range c.history.entries {
+		objs = append(objs, e.obj)
+	}
+	clear(c.history.entries)
+	c.history.entries = c.history.entries[:0]
+
+	return objs
+}
+
+// inHistory reports whether obj is kept by WithHistory.
+func (c *Container[T, PT]) inHistory(obj *T) bool {
+	if c.history.max == 0 {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.ContainsFunc(c.history.entries, func(e historyEntry[T]) bool { return e.obj == obj })
+}
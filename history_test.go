@@ -0,0 +1,72 @@
+package poolswap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestWithHistory(t *testing.T) {
+	pool := newMockPool()
+	objs := make([]*MockPayload, 5)
+	for i := range objs {
+		objs[i] = pool.Get()
+		objs[i].Recycled.Store(false)
+	}
+	container := poolswap.NewContainer(pool, objs[0], poolswap.WithHistory(2))
+	if container.History() != nil {
+		t.Fatal("nothing has been displaced yet")
+	}
+
+	for _, obj := range objs[1:] {
+		container.Update(obj) // displaces objs[0..3]
+	}
+
+	hist := container.History()
+	if len(hist) != 2 || hist[0] != objs[2] || hist[1] != objs[3] {
+		t.Fatalf("want the last 2 displaced objects, oldest first, got %v", hist)
+	}
+	if objs[2].DebugPeekRef() != 2 {
+		t.Fatalf("History should acquire a reference for the caller, Ref=%d", objs[2].DebugPeekRef())
+	}
+	container.ReleaseAll(hist)
+
+	if !objs[0].Recycled.Load() || !objs[1].Recycled.Load() {
+		t.Fatal("objects evicted from the history should drain")
+	}
+	if objs[2].Recycled.Load() || objs[3].Recycled.Load() {
+		t.Fatal("objects in the history must not be reset")
+	}
+
+	container.Close()
+	if !objs[2].Recycled.Load() || !objs[3].Recycled.Load() || container.History() != nil {
+		t.Fatal("Close should release the history")
+	}
+}
+
+func TestWithHistory_NotRetired(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get(), poolswap.WithHistory(1))
+	reader := container.Acquire()
+
+	container.Update(pool.Get())
+	if n := container.RetiredCount(); n != 0 {
+		t.Fatalf("an object in the history is not retired, RetiredCount=%d", n)
+	}
+
+	container.Update(pool.Get()) // evicts reader's object, still held
+	if n := container.RetiredCount(); n != 1 {
+		t.Fatalf("an evicted object still held by a reader is retired, RetiredCount=%d", n)
+	}
+	container.ForEachRetired(func(obj *MockPayload, outstanding int, _ time.Duration) {
+		if obj != reader || outstanding != 1 {
+			t.Errorf("want the reader's object with 1 reference, got %d refs", outstanding)
+		}
+	})
+
+	container.Release(reader)
+	if n := container.RetiredCount(); n != 0 || !reader.Recycled.Load() {
+		t.Fatalf("released object should drain, RetiredCount=%d", n)
+	}
+}
@@ -0,0 +1,139 @@
+package poolswap
+
+import (
+	"slices"
+	"sync"
+)
+
+// idleList is a bounded LIFO free list, used instead of a sync.Pool when the
+// number of idle objects must be known and bounded (WithMaxIdle).
+type idleList[T any] struct {
+	mu   sync.Mutex
+	max  int
+	objs []*T
+}
+
+func newIdleList[T any](maxIdle int) *idleList[T] {
+	return &idleList[T]{
+		mu:   sync.Mutex{},
+		max:  maxIdle,
+		objs: make([]*T, 0, maxIdle),
+	}
+}
+
+// push adds obj, reporting false if the list is full.
+func (l *idleList[T]) push(obj *T) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.objs) >= l.max {
+		return false
+	}
+	l.objs = append(l.objs, obj)
+
+	return true
+}
+
+// pop removes and returns the most recently pushed object, or nil.
+func (l *idleList[T]) pop() *T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := len(l.objs)
+	if n == 0 {
+		return nil
+	}
+	obj := l.objs[n-1]
+	l.objs[n-1] = nil
+	l.objs = l.objs[:n-1]
+
+	return obj
+}
+
+// drain drops all idle objects.
+func (l *idleList[T]) drain() {
+	l.mu.Lock()
+	clear(l.objs)
+	l.objs = l.objs[:0]
+	l.mu.Unlock()
+}
+
+// setMax changes the bound to maxIdle, removing and returning the surplus
+// (the least recently pushed objects) if the list holds more than that.
+func (l *idleList[T]) setMax(maxIdle int) []*T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.max = maxIdle
+	n := len(l.objs) - maxIdle
+	if n <= 0 {
+		return nil
+	}
+	surplus := slices.Clone(l.objs[:n])
+	kept := copy(l.objs, l.objs[n:])
+	clear(l.objs[kept:])
+	l.objs = l.objs[:kept]
+
+	return surplus
+}
+
+// len returns the number of idle objects.
+func (l *idleList[T]) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.objs)
+}
+
+// SetMaxIdle changes the WithMaxIdle bound of the pool's free list at runtime.
+// Lowering it below the number of idle objects discards the surplus immediately
+// (the least recently released objects go first); raising it lets the pool retain
+// more from now on. Safe for concurrent use with Get, Put and Release.
+//
+// It has no effect on a pool created without WithMaxIdle, whose free list is a
+// sync.Pool sized by the GC.
+func (p *Pool[T, PT]) SetMaxIdle(n int) {
+	if p.idle == nil {
+		return
+	}
+	for _, obj := range p.idle.setMax(max(n, 0)) {
+		p.discard(obj)
+	}
+}
+
+// Len returns the number of idle objects in the pool's free list (not counting
+// objects parked for a key, see WithAffinity), or -1 for a pool created without
+// WithMaxIdle, whose sync.Pool free list does not expose its size.
+func (p *Pool[T, PT]) Len() int {
+	if p.idle == nil {
+		return -1
+	}
+
+	return p.idle.len()
+}
+
+// NewChildPool creates a pool that shares parent's factory and Reset function, keeps
+// up to maxIdle idle objects of its own, and falls back on parent for the rest:
+// Get takes from the child's free list first, then from the parent's, and only then
+// allocates; released objects are kept by the child until it is full, then overflow
+// to the parent. Per-tenant child pools over one shared parent thus bound the
+// idle memory of all tenants together (if the parent uses WithMaxIdle too).
+//
+// opts configure the child (WithMaxIdle is implied by maxIdle; WithResetOnGet is
+// inherited from the parent, since idle objects move between the two; so are the
+// WithOnRetireSync hook and the WithNoPanic handler, unless opts set them). Objects obtained
+// from a child should be released through the child, or a container using it.
+func NewChildPool[T any, PT PtrRef[T]](parent *Pool[T, PT], maxIdle int, opts ...PoolOption) *Pool[T, PT] {
+	opts = append(opts[:len(opts):len(opts)], WithMaxIdle(maxIdle))
+	p := NewPool[T, PT](parent.newFunc(), parent.Reset, opts...)
+	p.parent = parent
+	p.resetOnGet = parent.resetOnGet
+	if p.onRetire.Load() == nil {
+		p.onRetire.Store(parent.onRetire.Load())
+	}
+	if p.onViolation == nil {
+		p.onViolation = parent.onViolation
+	}
+
+	return p
+}
@@ -0,0 +1,164 @@
+package poolswap_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func newBoundedPool(opts ...poolswap.PoolOption) *poolswap.Pool[MockPayload, *MockPayload] {
+	return poolswap.NewPool(
+		func() *MockPayload { return &MockPayload{} },
+		func(_ *MockPayload) bool { return true },
+		opts...,
+	)
+}
+
+func TestWithMaxIdle(t *testing.T) {
+	pool := newBoundedPool(poolswap.WithMaxIdle(2))
+
+	a, b, c := pool.Get(), pool.Get(), pool.Get()
+	pool.Release(a)
+	pool.Release(b)
+	pool.Release(c) // over the bound
+
+	if s := pool.StatsAndReset(); s.Puts != 2 || s.Discards != 1 {
+		t.Fatalf("want 2 puts and 1 discard, got %+v", s)
+	}
+	got := map[*MockPayload]bool{pool.Get(): true, pool.Get(): true}
+	if !got[a] || !got[b] {
+		t.Fatal("idle objects should be reused")
+	}
+	if s := pool.Stats(); s.News != 0 {
+		t.Fatalf("Gets should be served from the free list, News=%d", s.News)
+	}
+}
+
+func TestSetMaxIdle(t *testing.T) {
+	pool := newBoundedPool(poolswap.WithMaxIdle(4))
+	objs := []*MockPayload{pool.Get(), pool.Get(), pool.Get(), pool.Get()}
+	pool.ReleaseAll(objs)
+	if pool.Len() != 4 {
+		t.Fatalf("want 4 idle objects, got %d", pool.Len())
+	}
+
+	_ = pool.StatsAndReset()
+	pool.SetMaxIdle(1)
+	if pool.Len() != 1 {
+		t.Fatalf("lowering the bound should shrink the free list to 1, got %d", pool.Len())
+	}
+	if s := pool.Stats(); s.Discards != 3 {
+		t.Fatalf("the surplus should be discarded, got %+v", s)
+	}
+	if got := pool.Get(); got != objs[3] {
+		t.Fatal("the most recently released object should be kept")
+	}
+
+	pool.SetMaxIdle(3)
+	pool.ReleaseAll([]*MockPayload{pool.Get(), pool.Get(), pool.Get()})
+	if pool.Len() != 3 {
+		t.Fatalf("raising the bound should allow more idle objects, got %d", pool.Len())
+	}
+
+	unbounded := newBoundedPool()
+	unbounded.SetMaxIdle(1)
+	if unbounded.Len() != -1 {
+		t.Fatalf("a sync.Pool free list has no known length, got %d", unbounded.Len())
+	}
+}
+
+func TestSetMaxIdle_Concurrent(t *testing.T) {
+	pool := newBoundedPool(poolswap.WithMaxIdle(8))
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for range 200 {
+				pool.Release(pool.Get())
+			}
+		})
+	}
+	wg.Go(func() {
+		for i := range 200 {
+			pool.SetMaxIdle(i % 8)
+		}
+	})
+	wg.Wait()
+
+	pool.SetMaxIdle(2)
+	if n := pool.Len(); n > 2 {
+		t.Fatalf("free list exceeds its bound: %d", n)
+	}
+}
+
+func TestChildPool(t *testing.T) {
+	parent := newBoundedPool(poolswap.WithMaxIdle(10))
+	child := poolswap.NewChildPool(parent, 1)
+
+	a, b := child.Get(), child.Get()
+	child.Release(a) // kept by the child
+	child.Release(b) // child is full: overflows to the parent
+
+	if got := parent.Get(); got != b {
+		t.Fatal("object released into a full child should overflow to the parent")
+	}
+	if got := child.Get(); got != a {
+		t.Fatal("child should serve its own idle object first")
+	}
+
+	parent.Release(b) // back into the parent's free list
+	_ = child.StatsAndReset()
+	if got := child.Get(); got != b {
+		t.Fatal("empty child should take from the parent's free list")
+	}
+	if s := child.Stats(); s.News != 0 {
+		t.Fatalf("child should not allocate while the parent has idle objects, News=%d", s.News)
+	}
+
+	if fresh := child.Get(); fresh == nil || fresh == a || fresh == b {
+		t.Fatal("child should allocate once both free lists are empty")
+	}
+	if s := child.Stats(); s.News != 1 {
+		t.Fatalf("want 1 construction, News=%d", s.News)
+	}
+}
+
+func TestWithResetOnGet(t *testing.T) {
+	var resets int
+	keep := true
+	pool := poolswap.NewPool(
+		func() *MockPayload { return &MockPayload{} },
+		func(obj *MockPayload) bool {
+			resets++
+			obj.Content = obj.Content[:0]
+			return keep
+		},
+		poolswap.WithMaxIdle(4),
+		poolswap.WithResetOnGet(),
+	)
+
+	obj := pool.Get()
+	if resets != 0 {
+		t.Fatal("fresh objects should not be reset")
+	}
+	obj.Content = append(obj.Content, 1, 2, 3)
+	pool.Release(obj)
+	if resets != 0 || len(obj.Content) != 3 {
+		t.Fatal("release should pool the object without resetting it")
+	}
+
+	if got := pool.Get(); got != obj || resets != 1 || len(got.Content) != 0 {
+		t.Fatalf("Get should reset the pooled object before handing it out (resets=%d)", resets)
+	}
+
+	pool.Release(obj)
+	keep = false
+	_ = pool.StatsAndReset()
+	if got := pool.Get(); got == obj {
+		t.Fatal("object discarded by Reset on Get must not be handed out")
+	}
+	if s := pool.Stats(); s.Discards != 1 || s.News != 1 {
+		t.Fatalf("discard on Get should trigger a fresh allocation, got %+v", s)
+	}
+}
@@ -0,0 +1,61 @@
+package poolswap
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+)
+
+// Profiler label keys set by Container.Do when WithPprofLabels is enabled.
+const (
+	LabelContainer  = "poolswap.container"
+	LabelGeneration = "poolswap.generation"
+	LabelCaller     = "poolswap.caller"
+)
+
+// WithPprofLabels makes Container.Do tag the calling goroutine with pprof labels while
+// it holds its reference: the container's name (LabelContainer), the generation it
+// acquired (LabelGeneration), and Do's call site (LabelCaller). Goroutine and CPU profiles
+// then attribute work, and references, to their holders.
+//
+// This costs a caller lookup and a label context per Do, so it is off by default.
+func WithPprofLabels(name string) ContainerOption {
+	return func(o *containerOptions) {
+		o.pprofName = name
+	}
+}
+
+// Do acquires the current object, calls fn with it, and releases it afterwards
+// (also if fn panics). fn is not called if the container is empty; Do reports whether it ran.
+//
+// With WithPprofLabels, fn runs under pprof.Do: the goroutine carries the labels
+// (and ctx passed to fn has them) for exactly the time the reference is held,
+// and its previous labels are restored afterwards.
+func (c *Container[T, PT]) Do(ctx context.Context, fn func(ctx context.Context, obj *T)) bool {
+	obj, gen := c.AcquireWithGeneration()
+	if obj == nil {
+		return false
+	}
+	defer c.Release(obj)
+
+	if c.pprof == "" {
+		fn(ctx, obj)
+
+		return true
+	}
+
+	caller := "unknown"
+	if _, file, line, ok := runtime.Caller(1); ok {
+		caller = fmt.Sprintf("%s:%d", file, line)
+	}
+	labels := pprof.Labels(
+		LabelContainer, c.pprof,
+		LabelGeneration, strconv.FormatUint(gen, 10),
+		LabelCaller, caller,
+	)
+	pprof.Do(ctx, labels, func(ctx context.Context) { fn(ctx, obj) })
+
+	return true
+}
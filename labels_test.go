@@ -0,0 +1,62 @@
+package poolswap_test
+
+import (
+	"context"
+	"runtime/pprof"
+	"strings"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestDo_PprofLabels(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get(), poolswap.WithPprofLabels("config"))
+	container.Update(pool.Get())
+	container.Update(pool.Get()) // generation 2
+
+	ctx := context.Background()
+	ran := container.Do(ctx, func(ctx context.Context, got *MockPayload) {
+		if got.DebugPeekRef() != 2 {
+			t.Fatalf("reference should be held during fn, Ref=%d", got.DebugPeekRef())
+		}
+		if v, _ := pprof.Label(ctx, poolswap.LabelContainer); v != "config" {
+			t.Fatalf("want container label %q, got %q", "config", v)
+		}
+		if v, _ := pprof.Label(ctx, poolswap.LabelGeneration); v != "2" {
+			t.Fatalf("want generation label 2, got %q", v)
+		}
+		if v, _ := pprof.Label(ctx, poolswap.LabelCaller); !strings.Contains(v, "labels_test.go:") {
+			t.Fatalf("caller label should point at the call site, got %q", v)
+		}
+	})
+	if !ran {
+		t.Fatal("Do should run fn on a non-empty container")
+	}
+	if _, ok := pprof.Label(ctx, poolswap.LabelContainer); ok {
+		t.Fatal("labels must not leak into the caller's context")
+	}
+}
+
+func TestDo_WithoutLabels(t *testing.T) {
+	pool := newMockPool()
+	obj := pool.Get()
+	container := poolswap.NewContainer(pool, obj)
+
+	container.Do(context.Background(), func(ctx context.Context, got *MockPayload) {
+		if got != obj {
+			t.Fatal("Do should pass the current object")
+		}
+		if _, ok := pprof.Label(ctx, poolswap.LabelContainer); ok {
+			t.Fatal("labels should only be set with WithPprofLabels")
+		}
+	})
+	if obj.DebugPeekRef() != 1 {
+		t.Fatalf("reference should be released after Do, Ref=%d", obj.DebugPeekRef())
+	}
+
+	empty := poolswap.NewEmptyContainer(pool)
+	if empty.Do(context.Background(), func(context.Context, *MockPayload) { t.Fatal("must not run") }) {
+		t.Fatal("Do on an empty container should report false")
+	}
+}
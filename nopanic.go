@@ -0,0 +1,82 @@
+package poolswap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Invariant violations reported to the WithNoPanic handler.
+var (
+	// ErrRefUnderflow means an object was released more often than it was referenced.
+	ErrRefUnderflow = errors.New("poolswap: reference count dropped below zero")
+	// ErrCallbackPanic means a user-supplied function (factory, Reset, OnGrow callback) panicked.
+	ErrCallbackPanic = errors.New("poolswap: callback panicked")
+)
+
+// WithNoPanic hardens the pool's operations (Get, Put, Release and the drains
+// triggered by containers using the pool) for embedding in code that must not crash:
+// invariant violations and panics in user-supplied functions are reported to handler
+// instead, and the operation continues on a best-effort basis.
+//
+//   - A release that drops an object's count below zero (e.g. a double Release)
+//     reports ErrRefUnderflow and otherwise does nothing; the object is not pooled again.
+//   - An Acquire that finds the container's own reference gone (a reader released
+//     once too often, draining the installed object) reports ErrRefUnderflow.
+//   - A panicking Reset reports ErrCallbackPanic, and the object is discarded.
+//   - A panicking factory reports ErrCallbackPanic, and Get returns nil
+//     (as for a pool without a factory).
+//
+// handler is called synchronously and must not panic itself.
+// Recovering costs a deferred call per Reset and allocation, so this is off by default.
+func WithNoPanic(handler func(error)) PoolOption {
+	return func(o *poolOptions) {
+		o.onViolation = handler
+	}
+}
+
+// released checks the count an object was released to; 0 means the object drained.
+func (p *Pool[T, PT]) released(obj *T, refs int64) {
+	switch {
+	case refs == 0:
+		p.ownerOf(obj).drain(obj)
+	case refs < 0 && p.onViolation != nil:
+		p.onViolation(fmt.Errorf("%w (%d)", ErrRefUnderflow, refs))
+	}
+}
+
+// acquired checks the count an Acquire raised an installed object's count to;
+// the container's own reference keeps it above 1.
+func (p *Pool[T, PT]) acquired(refs int64) {
+	if refs <= 1 && p.onViolation != nil {
+		p.onViolation(fmt.Errorf("%w (the installed object had %d)", ErrRefUnderflow, refs-1))
+	}
+}
+
+// reset runs Reset on obj; with WithNoPanic, a panic counts as discarding obj.
+func (p *Pool[T, PT]) reset(obj *T) bool {
+	if p.onViolation != nil {
+		defer p.recoverCallback()
+	}
+
+	return p.Reset(obj)
+}
+
+// newObject runs factory (and the OnGrow callback); with WithNoPanic, a panic yields nil.
+func (p *Pool[T, PT]) newObject(factory func() *T) *T {
+	if p.onViolation != nil {
+		defer p.recoverCallback()
+	}
+	if p.onGrow != nil {
+		p.onGrow(int(p.grown.Add(1)))
+	}
+
+	return factory()
+}
+
+// recoverCallback reports a panic in a user-supplied function to the WithNoPanic handler.
+// It must be deferred directly.
+func (p *Pool[T, PT]) recoverCallback() {
+	if r := recover(); r != nil {
+		p.onViolation(fmt.Errorf("%w: %v", ErrCallbackPanic, r))
+	}
+}
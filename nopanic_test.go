@@ -0,0 +1,88 @@
+package poolswap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestWithNoPanic_RefUnderflow(t *testing.T) {
+	var violations []error
+	pool := poolswap.NewPool(
+		func() *MockPayload { return new(MockPayload) },
+		func(*MockPayload) bool { return true },
+		poolswap.WithNoPanic(func(err error) { violations = append(violations, err) }),
+	)
+	obj, next := pool.Get(), pool.Get()
+	container := poolswap.NewContainer(pool, obj)
+
+	r := container.Acquire()
+	container.Release(r)
+	container.Release(r) // drains obj while the container still holds it
+
+	r = container.Acquire()
+	if len(violations) != 1 {
+		t.Fatalf("Acquire should report the installed object's lost reference, got %v", violations)
+	}
+	container.ReleaseAll([]*MockPayload{r, r, r})
+	container.Update(next) // retires the corrupted object
+
+	if len(violations) != 3 {
+		t.Fatalf("want 3 reported violations, got %v", violations)
+	}
+	for _, err := range violations {
+		if !errors.Is(err, poolswap.ErrRefUnderflow) {
+			t.Fatalf("want ErrRefUnderflow, got %v", err)
+		}
+	}
+}
+
+func TestWithNoPanic_CallbackPanics(t *testing.T) {
+	var violations []error
+	fail := false
+	pool := poolswap.NewPool(
+		func() *MockPayload {
+			if fail {
+				panic("factory")
+			}
+
+			return new(MockPayload)
+		},
+		func(*MockPayload) bool { panic("reset") },
+		poolswap.WithNoPanic(func(err error) { violations = append(violations, err) }),
+	)
+
+	pool.Release(pool.Get())
+	if s := pool.Stats(); s.Discards != 1 || len(violations) != 1 {
+		t.Fatalf("a panicking Reset should discard the object, got %+v, %v", s, violations)
+	}
+
+	fail = true
+	if obj := pool.Get(); obj != nil {
+		t.Fatal("Get should return nil when the factory panics")
+	}
+	if len(violations) != 2 {
+		t.Fatalf("want 2 reported violations, got %v", violations)
+	}
+	for _, err := range violations {
+		if !errors.Is(err, poolswap.ErrCallbackPanic) {
+			t.Fatalf("want ErrCallbackPanic, got %v", err)
+		}
+	}
+}
+
+func TestWithoutNoPanic_ResetPanicPropagates(t *testing.T) {
+	pool := poolswap.NewPool(
+		func() *MockPayload { return new(MockPayload) },
+		func(*MockPayload) bool { panic("reset") },
+	)
+	obj := pool.Get()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("without WithNoPanic, a Reset panic should propagate")
+		}
+	}()
+	pool.Release(obj)
+}
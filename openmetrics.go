@@ -0,0 +1,41 @@
+package poolswap
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteOpenMetrics writes the container's State to w in the OpenMetrics text format,
+// as a complete exposition (terminated by "# EOF") that can be served as is, e.g. by a
+// small HTTP handler, without a metrics client dependency. The metrics are named
+// prefix_generation, prefix_outstanding and prefix_retired (gauges), and prefix_status
+// (a stateset); prefix must be a valid metric name, e.g. "myapp_config".
+func (c *Container[T, PT]) WriteOpenMetrics(w io.Writer, prefix string) error {
+	st := c.State()
+
+	var b strings.Builder
+	gauge := func(name, help string, v any) {
+		fmt.Fprintf(&b, "# TYPE %s_%s gauge\n# HELP %s_%s %s\n%s_%s %v\n", prefix, name, prefix, name, help, prefix, name, v)
+	}
+	gauge("generation", "Generation of the current object.", st.Generation)
+	gauge("outstanding", "References readers hold on the current object.", st.Outstanding)
+	gauge("retired", "Swapped-out objects still held by readers.", st.Retired)
+
+	fmt.Fprintf(&b, "# TYPE %s_status stateset\n# HELP %s_status Lifecycle state of the container.\n", prefix, prefix)
+	for _, s := range []Status{StatusActive, StatusClosing, StatusClosed} {
+		v := 0
+		if s == st.Status {
+			v = 1
+		}
+		fmt.Fprintf(&b, "%s_status{%s_status=%q} %d\n", prefix, prefix, s, v)
+	}
+	b.WriteString("# EOF\n")
+
+	_, err := io.WriteString(w, b.String())
+	if err != nil {
+		return fmt.Errorf("poolswap: writing metrics: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package poolswap_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+var (
+	metricsMetaRe   = regexp.MustCompile(`^# (TYPE|HELP) ([a-zA-Z_:][a-zA-Z0-9_:]*) (.+)$`)
+	metricsSampleRe = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)(\{[a-zA-Z_][a-zA-Z0-9_]*="[^"]*"\})? (-?[0-9]+)$`)
+)
+
+// parseOpenMetrics checks the subset of the OpenMetrics text format WriteOpenMetrics uses,
+// returning the samples by name (with labels).
+func parseOpenMetrics(t *testing.T, text string) map[string]string {
+	t.Helper()
+	if !strings.HasSuffix(text, "# EOF\n") {
+		t.Fatalf("exposition must end with # EOF:\n%s", text)
+	}
+	types := map[string]string{}
+	samples := map[string]string{}
+	for line := range strings.SplitSeq(strings.TrimSuffix(text, "# EOF\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		if m := metricsMetaRe.FindStringSubmatch(line); m != nil {
+			if m[1] == "TYPE" {
+				types[m[2]] = m[3]
+			} else if types[m[2]] == "" {
+				t.Fatalf("HELP before TYPE for %s", m[2])
+			}
+
+			continue
+		}
+		m := metricsSampleRe.FindStringSubmatch(line)
+		if m == nil {
+			t.Fatalf("invalid line %q", line)
+		}
+		if types[m[1]] == "" {
+			t.Fatalf("sample %s without TYPE", m[1])
+		}
+		samples[m[1]+m[2]] = m[3]
+	}
+
+	return samples
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+	reader := container.Acquire()
+	container.Update(pool.Get())
+	container.Update(pool.Get())
+	defer container.Release(reader)
+	current := container.Acquire()
+	defer container.Release(current)
+
+	var b strings.Builder
+	err := container.WriteOpenMetrics(&b, "app_config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samples := parseOpenMetrics(t, b.String())
+	want := map[string]string{
+		"app_config_generation":                         "2",
+		"app_config_outstanding":                        "1",
+		"app_config_retired":                            "1",
+		`app_config_status{app_config_status="active"}`: "1",
+		`app_config_status{app_config_status="closed"}`: "0",
+	}
+	for name, v := range want {
+		if samples[name] != v {
+			t.Errorf("%s: want %s, got %q", name, v, samples[name])
+		}
+	}
+}
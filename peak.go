@@ -0,0 +1,40 @@
+package poolswap
+
+// WithMaxRefs calls onExceed whenever an Acquire takes the current object's reference
+// count (including the container's own reference) above n, e.g. to flag a reference
+// leak or unexpected fan-out. It fires once per crossing: again only after the count
+// has dropped to n or below and then exceeds it once more.
+//
+// onExceed is called synchronously from Acquire, after the read lock is released,
+// so it may use the container.
+func WithMaxRefs(n int64, onExceed func()) ContainerOption {
+	return func(o *containerOptions) {
+		o.maxRefs = n
+		o.onExceed = onExceed
+	}
+}
+
+// PeakRefs returns the highest reference count (including the container's own reference)
+// that Acquire has observed on the current object since it was installed,
+// or 0 if it has not been acquired since.
+func (c *Container[T, PT]) PeakRefs() int64 {
+	return c.peak.Load()
+}
+
+// notePeak raises the peak to refs if that is higher. c.mu must be read-locked.
+func (c *Container[T, PT]) notePeak(refs int64) {
+	for {
+		peak := c.peak.Load()
+		if refs <= peak || c.peak.CompareAndSwap(peak, refs) {
+			return
+		}
+	}
+}
+
+// checkMaxRefs calls the WithMaxRefs callback if an Acquire took the count to refs,
+// crossing the threshold.
+func (c *Container[T, PT]) checkMaxRefs(refs int64) {
+	if c.onExceed != nil && refs == c.maxRefs+1 {
+		c.onExceed()
+	}
+}
@@ -0,0 +1,53 @@
+package poolswap_test
+
+import (
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestPeakRefs(t *testing.T) {
+	pool := newMockPool()
+	exceeded := 0
+	container := poolswap.NewContainer(pool, pool.Get(), poolswap.WithMaxRefs(4, func() { exceeded++ }))
+
+	if container.PeakRefs() != 0 {
+		t.Fatalf("no Acquire yet, got peak %d", container.PeakRefs())
+	}
+
+	held := make([]*MockPayload, 0, 5)
+	for range 3 {
+		held = append(held, container.Acquire()) // Ref=4
+	}
+	if exceeded != 0 {
+		t.Fatal("the threshold is not crossed yet")
+	}
+	obj, _ := container.AcquireWithGeneration() // Ref=5
+	held = append(held, obj)
+	if exceeded != 1 {
+		t.Fatalf("crossing the threshold should call onExceed once, got %d", exceeded)
+	}
+	held = append(held, container.Acquire()) // Ref=6
+	if exceeded != 1 || container.PeakRefs() != 6 {
+		t.Fatalf("want 1 call and peak 6, got %d calls and peak %d", exceeded, container.PeakRefs())
+	}
+
+	container.ReleaseAll(held[1:])
+	if container.PeakRefs() != 6 {
+		t.Fatal("releases must not lower the peak")
+	}
+	held = append(held[:1], container.Acquire(), container.Acquire(), container.Acquire()) // Ref=5 again
+	if exceeded != 2 {
+		t.Fatalf("crossing again should call onExceed again, got %d", exceeded)
+	}
+	container.ReleaseAll(held)
+
+	container.Update(pool.Get())
+	if container.PeakRefs() != 0 {
+		t.Fatalf("the peak should restart with the new object, got %d", container.PeakRefs())
+	}
+	container.Release(container.Acquire())
+	if container.PeakRefs() != 2 {
+		t.Fatalf("want peak 2 for the new object, got %d", container.PeakRefs())
+	}
+}
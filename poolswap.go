@@ -10,28 +10,66 @@ package poolswap
 import (
 	"sync"
 	"sync/atomic"
+	"weak"
 )
 
 // Ref should be embedded as the first field in structs you want to use with this library.
 // Includes cache-line padding to prevent false sharing on the counter.
 type Ref struct {
-	count atomic.Int64
-	_     [56]byte // Padding to fill 64-byte cache line
+	count     atomic.Int64
+	lives     atomic.Uint64 // bumped each time a Pool hands the object out
+	poolEpoch uint64        // the Pool epoch the object belongs to (see Pool.Recycle); 0 if unknown
+	owner     any           // the *Pool that handed the object out; nil if unknown
+	out       bool          // counted as in use by owner until it drains (see WithMaxTotal)
+	_         [23]byte      // Padding to fill 64-byte cache line
 }
 
 func (r *Ref) addRef(delta int64) int64 { return r.count.Add(delta) }
 func (r *Ref) setRef(v int64)           { r.count.Store(v) }
+func (r *Ref) refs() int64              { return r.count.Load() }
+func (r *Ref) issue()                   { r.lives.Add(1) }
+func (r *Ref) life() uint64             { return r.lives.Load() }
+func (r *Ref) epoch() uint64            { return r.poolEpoch }
+func (r *Ref) setEpoch(e uint64)        { r.poolEpoch = e }
+func (r *Ref) pool() any                { return r.owner }
+func (r *Ref) setPool(p any)            { r.owner = p }
+
+// setHandedOut marks whether the owner counts the object as in use, returning the previous mark.
+func (r *Ref) setHandedOut(v bool) bool {
+	old := r.out
+	r.out = v
+
+	return old
+}
 
 // DebugPeekRef returns the current reference count; for testing and debugging only.
 func (r *Ref) DebugPeekRef() int64 { return r.count.Load() }
 
 // RefNoPadding is the same as Ref, but without the padding.
 type RefNoPadding struct {
-	count atomic.Int64
+	count     atomic.Int64
+	lives     atomic.Uint64
+	poolEpoch uint64
+	owner     any
+	out       bool
 }
 
 func (r *RefNoPadding) addRef(delta int64) int64 { return r.count.Add(delta) }
 func (r *RefNoPadding) setRef(v int64)           { r.count.Store(v) }
+func (r *RefNoPadding) refs() int64              { return r.count.Load() }
+func (r *RefNoPadding) issue()                   { r.lives.Add(1) }
+func (r *RefNoPadding) life() uint64             { return r.lives.Load() }
+func (r *RefNoPadding) epoch() uint64            { return r.poolEpoch }
+func (r *RefNoPadding) setEpoch(e uint64)        { r.poolEpoch = e }
+func (r *RefNoPadding) pool() any                { return r.owner }
+func (r *RefNoPadding) setPool(p any)            { r.owner = p }
+
+func (r *RefNoPadding) setHandedOut(v bool) bool {
+	old := r.out
+	r.out = v
+
+	return old
+}
 
 // DebugPeekRef returns the current reference count; for testing and debugging only.
 func (r *RefNoPadding) DebugPeekRef() int64 { return r.count.Load() }
@@ -41,6 +79,14 @@ func (r *RefNoPadding) DebugPeekRef() int64 { return r.count.Load() }
 type Referenceable interface {
 	addRef(delta int64) int64
 	setRef(v int64)
+	refs() int64
+	issue()
+	life() uint64
+	epoch() uint64
+	setEpoch(e uint64)
+	pool() any
+	setPool(p any)
+	setHandedOut(v bool) bool
 }
 
 // PtrRef is a pointer type that is Referenceable (embeds Ref or RefNoPadding).
@@ -52,52 +98,382 @@ type PtrRef[T any] interface {
 // Pool wraps a sync.Pool with reference counting.
 //
 // When an object's reference count hits zero, the Pool cleans it via the Reset
-// function and returns it to the internal sync.Pool.
+// function and returns it to its free list: an internal sync.Pool, or a bounded
+// list with WithMaxIdle.
 //
 // T is the struct type (e.g., MyCache).
 // PT is the pointer type (e.g., *MyCache).
 type Pool[T any, PT PtrRef[T]] struct {
 	internal sync.Pool
-	// Reset is called when refs hit 0.
+	idle     *idleList[T] // bounded free list used instead of internal (WithMaxIdle)
+	parent   *Pool[T, PT] // overflow pool (NewChildPool)
+	// factory is nil if the pool never allocates (see SetNewFunc).
+	factory  atomic.Pointer[func() *T]
+	stats    poolStats
+	affinity *affinity[T]
+	epoch    atomic.Uint64 // bumped by Recycle
+	onGrow   func(newSize int)
+	grown    atomic.Int64 // constructions reported to onGrow
+	limit    *capacity    // objects in use, if bounded (WithMaxTotal)
+	// onViolation receives invariant violations instead of panics (WithNoPanic).
+	onViolation func(error)
+	// onRetire flushes a drained object before it is reset; on error it is discarded
+	// (WithOnRetireSync, SetOnRetireSync).
+	onRetire atomic.Pointer[func(*T) error]
+	// resetOnGet defers Reset from the drain to the next Get (WithResetOnGet);
+	// idle objects are dirty then.
+	resetOnGet bool
+	// Reset is called when refs hit 0 (or, with WithResetOnGet, when Get reuses the object).
 	// It should clear the object's state (e.g. clear maps, reset slices).
 	// Return true to put it back in the pool, false to discard (GC).
 	Reset func(*T) bool
 }
 
+// PoolOption configures optional Pool behavior.
+type PoolOption func(*poolOptions)
+
+type poolOptions struct {
+	affinityKeys int
+	onGrow       func(newSize int)
+	maxIdle      int
+	boundIdle    bool
+	resetOnGet   bool
+	maxTotal     int
+	boundTotal   bool
+	onViolation  func(error)
+	onRetireSync any // func(*T) error
+}
+
+// WithAffinity enables Pool.GetForKey, tracking up to maxKeys objects by key.
+// Objects parked for a key are held outside the regular free list
+// (and so are not reclaimed by the GC) until requested by that key again.
+func WithAffinity(maxKeys int) PoolOption {
+	return func(o *poolOptions) {
+		o.affinityKeys = maxKeys
+	}
+}
+
+// WithOnGrow calls fn whenever Get has to construct a new object because the pool
+// had nothing to recycle, with the running total of objects constructed so far.
+// Frequent calls indicate churn exceeding what the pool retains.
+// fn is called synchronously from Get.
+func WithOnGrow(fn func(newSize int)) PoolOption {
+	return func(o *poolOptions) {
+		o.onGrow = fn
+	}
+}
+
+// WithMaxIdle bounds the pool's free list to n objects. Objects released into a
+// full pool are discarded (or, for a child pool, overflow to its parent).
+//
+// Without it, the free list is a sync.Pool: unbounded, but emptied by the GC.
+// With it, up to n idle objects are retained regardless of GC cycles.
+func WithMaxIdle(n int) PoolOption {
+	return func(o *poolOptions) {
+		o.maxIdle = max(n, 0)
+		o.boundIdle = true
+	}
+}
+
+// WithResetOnGet moves the Reset call from the releasing side to the acquiring side:
+// a drained object goes back to the free list as-is, and Get resets it before handing
+// it out. If Reset returns false there, the object is discarded and Get tries the next
+// one (or allocates). This takes reset cost off readers, whose final Release would
+// otherwise pay it, which can improve reader tail latency (see BenchmarkResetOnGet);
+// the price is that idle objects hold on to their old contents until reused.
+//
+// Objects discarded without reuse (e.g. after Recycle) are not reset in this mode.
+func WithResetOnGet() PoolOption {
+	return func(o *poolOptions) {
+		o.resetOnGet = true
+	}
+}
+
 // NewPool creates a pool for type T.
-// factory allocates a new, empty T.
+// factory allocates a new, empty T. If factory is nil, the pool never allocates:
+// it only recycles objects fed to it via Put (and drained objects), and Get returns
+// nil when it has nothing to recycle.
 // resetter prepares a used T for reuse (or returns false to discard it).
-func NewPool[T any, PT PtrRef[T]](factory func() *T, resetter func(*T) bool) *Pool[T, PT] {
-	return &Pool[T, PT]{
-		internal: sync.Pool{
-			New: func() any { return factory() },
-		},
-		Reset: resetter,
+func NewPool[T any, PT PtrRef[T]](factory func() *T, resetter func(*T) bool, opts ...PoolOption) *Pool[T, PT] {
+	var o poolOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := &Pool[T, PT]{
+		internal: sync.Pool{New: nil},
+		idle:     nil,
+		parent:   nil,
+		factory:  atomic.Pointer[func() *T]{},
+		stats:    newPoolStats(),
+		affinity: nil,
+		epoch:    atomic.Uint64{},
+		onGrow:   o.onGrow,
+		grown:    atomic.Int64{},
+		limit:    nil,
+		Reset:    resetter,
+
+		resetOnGet:  o.resetOnGet,
+		onViolation: o.onViolation,
+		onRetire:    atomic.Pointer[func(*T) error]{},
+	}
+	if o.onRetireSync != nil {
+		fn, ok := o.onRetireSync.(func(*T) error)
+		if !ok {
+			panic("poolswap: WithOnRetireSync function does not match the pool's object type")
+		}
+		p.SetOnRetireSync(fn)
+	}
+	p.SetNewFunc(factory)
+	if o.affinityKeys > 0 {
+		p.affinity = newAffinity[T](o.affinityKeys)
 	}
+	if o.boundIdle {
+		p.idle = newIdleList[T](o.maxIdle)
+	}
+	if o.boundTotal {
+		p.limit = newCapacity(o.maxTotal)
+	}
+
+	return p
 }
 
-// Release decrements the ref count. If it hits 0, the object is returned to the pool.
+// Release decrements the ref count. If it hits 0, the object is returned to the pool
+// that handed it out (which may be a different Pool of the same type; see Get),
+// or to p if the object did not come from a Pool's Get.
 // Safe to call with nil.
 func (p *Pool[T, PT]) Release(obj *T) {
 	if obj == nil {
 		return
 	}
-	if PT(obj).addRef(-1) == 0 {
-		p.returnToPool(obj)
+	p.released(obj, PT(obj).addRef(-1))
+}
+
+// ReleaseAll releases every reference in objs (nil entries are skipped), as calling
+// Release for each would, but applies one combined atomic subtraction per distinct
+// object. Objects whose count drops to zero are returned to the pool.
+func (p *Pool[T, PT]) ReleaseAll(objs []*T) {
+	counts := make(map[*T]int64, len(objs))
+	order := make([]*T, 0, len(objs))
+	for _, obj := range objs {
+		if obj == nil {
+			continue
+		}
+		if counts[obj] == 0 {
+			order = append(order, obj)
+		}
+		counts[obj]++
+	}
+	for _, obj := range order {
+		p.released(obj, PT(obj).addRef(-counts[obj]))
 	}
 }
 
 // Get acquires a fresh object from the pool with Ref=1.
+// The object remembers p as its owner: when its last reference is released,
+// it returns to p, whichever Pool or Container the release goes through.
+// This keeps migrations between pools (e.g. a Container updated with objects
+// from a new pool) from moving objects into the wrong pool.
+//
+// Returns nil if the pool has no factory and no object to recycle.
 func (p *Pool[T, PT]) Get() *T {
-	r := p.internal.Get().(*T) //nolint:forcetypeassert
-	PT(r).setRef(1)
+	r := p.takeAny()
+	if r == nil {
+		r = p.construct()
+	}
+	if r == nil {
+		return nil
+	}
+	p.handOut(r)
 
 	return r
 }
 
-func (p *Pool[T, PT]) returnToPool(obj *T) {
-	if p.Reset(obj) {
+// takeAny returns an idle object from the pool or its parent, or nil if there is none.
+func (p *Pool[T, PT]) takeAny() *T {
+	if r := p.takeIdle(); r != nil {
+		return r
+	}
+	if p.parent == nil {
+		return nil
+	}
+	r := p.parent.takeIdle()
+	if r != nil {
+		PT(r).setEpoch(p.currentEpoch())
+	}
+
+	return r
+}
+
+// takeIdle returns an object from the free list, or nil if it is empty.
+func (p *Pool[T, PT]) takeIdle() *T {
+	for {
+		var r *T
+		if p.idle != nil {
+			r = p.idle.pop()
+		} else if v := p.internal.Get(); v != nil {
+			r = v.(*T) //nolint:forcetypeassert
+		}
+		if r == nil {
+			return nil
+		}
+		if p.reusable(r) {
+			return r
+		}
+	}
+}
+
+// reusable reports whether an idle object may be handed out again, running Reset
+// first in WithResetOnGet mode. Objects that may not are dropped.
+func (p *Pool[T, PT]) reusable(obj *T) bool {
+	if p.stale(obj) { // pooled before a Recycle
+		return false
+	}
+	if p.resetOnGet && !p.reset(obj) {
+		p.discard(obj)
+
+		return false
+	}
+
+	return true
+}
+
+// putIdle adds a clean object to the free list; if it is full, the object
+// overflows to the parent pool. Reports false if nobody had room for it.
+func (p *Pool[T, PT]) putIdle(obj *T) bool {
+	if p.idle == nil {
 		p.internal.Put(obj)
+
+		return true
+	}
+	if p.idle.push(obj) {
+		return true
+	}
+	if p.parent != nil {
+		PT(obj).setEpoch(p.parent.currentEpoch())
+
+		return p.parent.putIdle(obj)
+	}
+
+	return false
+}
+
+// construct allocates a new object with the factory; nil if there is none.
+func (p *Pool[T, PT]) construct() *T {
+	factory := p.newFunc()
+	if factory == nil {
+		return nil
+	}
+	p.stats.news.Add(1)
+	obj := p.newObject(factory)
+	if obj == nil {
+		return nil
+	}
+	PT(obj).setEpoch(p.currentEpoch())
+
+	return obj
+}
+
+// Put hands an unreferenced object to the pool, as if its last reference had
+// just been released: it is cleaned via Reset and kept for reuse (or discarded).
+// This is how a pool without a factory is fed. Safe to call with nil.
+//
+// The object must not be referenced by anyone, including a Container.
+func (p *Pool[T, PT]) Put(obj *T) {
+	if obj == nil {
+		return
+	}
+	PT(obj).setEpoch(p.currentEpoch())
+	p.returnToPool(obj)
+	if p.limit != nil {
+		p.limit.signal()
+	}
+}
+
+// adopt stamps obj, taken into custody without coming from Get (e.g. the initial object
+// of a Container), with the current epoch, so that it is discarded on release after
+// a Recycle like any object handed out before it.
+func (p *Pool[T, PT]) adopt(obj *T) {
+	if obj != nil && PT(obj).epoch() == 0 {
+		PT(obj).setEpoch(p.currentEpoch())
+	}
+}
+
+// handOut prepares an object leaving the pool and counts it as in use.
+func (p *Pool[T, PT]) handOut(obj *T) {
+	if p.limit != nil {
+		p.limit.take()
+	}
+	p.prepare(obj)
+}
+
+// prepare gives an object leaving the pool a new life, Ref=1, and p as its owner,
+// marking it as counted in use by p.
+func (p *Pool[T, PT]) prepare(obj *T) {
+	PT(obj).issue()
+	PT(obj).setPool(p)
+	PT(obj).setHandedOut(true)
+	PT(obj).setRef(1)
+	p.stats.gets.Add(1)
+}
+
+// ownerOf returns the pool obj was handed out by, or p if it is unknown.
+func (p *Pool[T, PT]) ownerOf(obj *T) *Pool[T, PT] {
+	if owner, ok := PT(obj).pool().(*Pool[T, PT]); ok && owner != nil {
+		return owner
+	}
+
+	return p
+}
+
+// drain takes back an object handed out by p whose last reference was dropped.
+func (p *Pool[T, PT]) drain(obj *T) {
+	counted := p.takeBack(obj) // before the object is pooled and handed out again
+	p.returnToPool(obj)
+	if counted {
+		p.checkIn()
+	}
+}
+
+// takeBack clears obj's in-use mark, reporting whether p counted it as in use.
+// Objects adopted from elsewhere (e.g. the initial object of a Container) never were.
+func (p *Pool[T, PT]) takeBack(obj *T) bool {
+	return PT(obj).setHandedOut(false)
+}
+
+// checkIn ends the in-use period of an object handed out by p (see WithMaxTotal).
+func (p *Pool[T, PT]) checkIn() {
+	if p.limit != nil {
+		p.limit.done()
+	}
+}
+
+func (p *Pool[T, PT]) returnToPool(obj *T) {
+	if !p.flushed(obj) || (!p.resetOnGet && !p.reset(obj)) || p.stale(obj) {
+		p.discard(obj)
+
+		return
+	}
+	if p.affinity != nil {
+		obj = p.affinity.park(obj)
+		if obj == nil {
+			p.stats.puts.Add(1)
+
+			return
+		}
+	}
+	if !p.putIdle(obj) {
+		p.discard(obj)
+
+		return
+	}
+	p.stats.puts.Add(1)
+}
+
+func (p *Pool[T, PT]) discard(obj *T) {
+	p.stats.discards.Add(1)
+	if p.affinity != nil {
+		p.affinity.forget(obj)
 	}
 }
 
@@ -106,48 +482,251 @@ type Container[T any, PT PtrRef[T]] struct {
 	pool    *Pool[T, PT]
 	mu      sync.RWMutex
 	current PT
+	gen     uint64 // bumped by every Update; guarded by mu
+	status  Status // set by Close, settled by Status; guarded by mu
+	retired retiredList[T, PT]
+	spin    int
+	pprof   string // container name for pprof labels (WithPprofLabels); "" if disabled
+
+	spare PT // displaced object kept by RecycleInto, holding the container's reference; guarded by mu
+
+	subsMu sync.Mutex
+	subs   []weak.Pointer[Subscription]
+
+	coalesce coalescer[T] // pending UpdateCoalescing
+
+	history history[T] // WithHistory; guarded by mu
+
+	peak     atomic.Int64 // highest count Acquire saw on current; reset (under mu) when current changes
+	maxRefs  int64        // WithMaxRefs threshold, if onExceed is set
+	onExceed func()
 }
 
-// NewEmptyContainer creates a container for objects from the given Pool.
-// The container starts empty (current is nil) until Update is called.
-func NewEmptyContainer[T any, PT PtrRef[T]](pool *Pool[T, PT]) *Container[T, PT] {
+// ContainerOption configures optional Container behavior.
+type ContainerOption func(*containerOptions)
+
+type containerOptions struct {
+	acquireSpin int
+	pprofName   string
+	history     int
+	maxRefs     int64
+	onExceed    func()
+}
+
+// WithAcquireSpin makes Acquire retry taking the read lock up to n times before
+// parking when it finds an Update in progress (see Acquire). Since Update holds the
+// lock only for a pointer swap, spinning briefly can beat parking on short critical
+// sections; see BenchmarkAcquireSpin. The default (0) parks immediately.
+func WithAcquireSpin(n int) ContainerOption {
+	return func(o *containerOptions) {
+		o.acquireSpin = n
+	}
+}
+
+func newContainer[T any, PT PtrRef[T]](pool *Pool[T, PT], init PT, opts []ContainerOption) *Container[T, PT] {
+	var o containerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return &Container[T, PT]{
 		pool:    pool,
 		mu:      sync.RWMutex{},
-		current: nil,
+		current: init,
+		gen:     0,
+		status:  StatusActive,
+		retired: newRetiredList[T, PT](),
+		spin:    o.acquireSpin,
+		pprof:   o.pprofName,
+		spare:   nil,
+		subsMu:  sync.Mutex{},
+		subs:    nil,
+
+		coalesce: coalescer[T]{mu: sync.Mutex{}, pending: nil, armed: false},
+		history:  history[T]{max: o.history, entries: nil},
+
+		peak:     atomic.Int64{},
+		maxRefs:  o.maxRefs,
+		onExceed: o.onExceed,
 	}
 }
 
+// NewEmptyContainer creates a container for objects from the given Pool.
+// The container starts empty (current is nil) until Update is called.
+func NewEmptyContainer[T any, PT PtrRef[T]](pool *Pool[T, PT], opts ...ContainerOption) *Container[T, PT] {
+	return newContainer(pool, nil, opts)
+}
+
 // NewContainer creates a container for objects from the given Pool, initialized
 // with the init object.
 //
 // The object must be not be owned by another instance of poolswap.Container;
 // The container takes ownership of the given initial value (reference count set to 1).
-func NewContainer[T any, PT PtrRef[T]](pool *Pool[T, PT], init PT) *Container[T, PT] {
+func NewContainer[T any, PT PtrRef[T]](pool *Pool[T, PT], init PT, opts ...ContainerOption) *Container[T, PT] {
 	if init != nil {
 		init.setRef(1)
+		pool.adopt(init)
 	}
 
-	return &Container[T, PT]{
-		pool:    pool,
-		mu:      sync.RWMutex{},
-		current: init,
+	return newContainer(pool, init, opts)
+}
+
+// New creates a pool for type T and a container using it (reachable via Container.Pool),
+// for the common case of a single container per pool.
+// factory and resetter are as for NewPool.
+//
+// The container is initialized with initial (at most one object, taking ownership as NewContainer does),
+// or, if none is given, with a fresh object from the pool.
+func New[T any, PT PtrRef[T]](factory func() *T, resetter func(*T) bool, initial ...*T) *Container[T, PT] {
+	pool := NewPool[T, PT](factory, resetter)
+	if len(initial) > 0 {
+		return NewContainer(pool, PT(initial[0]))
 	}
+
+	return NewContainer(pool, PT(pool.Get()))
+}
+
+// Pool returns the pool the container draws objects from and returns them to.
+func (c *Container[T, PT]) Pool() *Pool[T, PT] {
+	return c.pool
 }
 
 // Update the container to point at a new object.
 //
 // It sets the new object as current and releases the old object.
 // The old object will be returned to the pool once all existing readers release it.
+//
+// Passing nil empties the container (Acquire returns nil until the next Update).
+// Note that GetNew returns nil when the pool has no factory and nothing to recycle.
+// After Close, newObj is released to the pool instead of being installed.
+//
+// Memory model: the swap happens under the container's write lock, which excludes
+// every Acquire. When Update returns, the swap is complete and visible: any Acquire
+// that starts after it, in any goroutine that has heard of the return (through
+// any synchronizing event, e.g. an atomic or a channel), gets newObj or a later object,
+// never the old one, and sees all writes made to newObj before Update was called.
+// Acquires that returned the old object did so before the swap, holding their own reference.
 func (c *Container[T, PT]) Update(newObj *T) {
+	c.UpdateG(newObj)
+}
+
+// UpdateValidated runs validate on newObj and installs it (as Update does) only if
+// validate returns nil. Otherwise newObj is released to the pool, the current
+// object is left in place, and the validation error is returned.
+//
+// Returns ErrClosed (after releasing newObj) if the container is closed.
+func (c *Container[T, PT]) UpdateValidated(newObj *T, validate func(*T) error) error {
+	err := validate(newObj)
+	if err != nil {
+		c.pool.Release(newObj)
+
+		return err
+	}
+	if _, ok := c.install(newObj); !ok {
+		return ErrClosed
+	}
+
+	return nil
+}
+
+// SwapIfNewer installs newObj (as Update does) only if the container is empty or
+// less(current, newObj) reports that the current object is older, e.g. by comparing
+// version numbers or timestamps carried by the objects. Otherwise, or if the container
+// is closed, newObj is released to the pool and SwapIfNewer returns false.
+//
+// less runs under the container's write lock, so the check and the swap are atomic:
+// of several concurrent, out-of-order SwapIfNewer calls, the newest object always wins.
+// less must be fast and must not call back into the container.
+func (c *Container[T, PT]) SwapIfNewer(newObj *T, less func(cur, newObj *T) bool) bool {
+	_, ok := c.installIf(newObj, func(cur *T) bool {
+		return cur == nil || less(cur, newObj)
+	})
+
+	return ok
+}
+
+// Reset puts the container back into its initial state with initial as the current
+// object (nil for empty): the generation restarts at 0, and the displaced object
+// (plus any object kept by RecycleInto) is retired as by Update. This lets test fixtures
+// and periodic hard resets reuse one container instead of constructing a new one.
+// Use Update instead if generations must stay monotonic.
+//
+// Like NewContainer, Reset takes ownership of initial (reference count set to 1).
+// Outstanding references to displaced objects remain valid until released.
+// On a closed container, initial is released instead.
+func (c *Container[T, PT]) Reset(initial PT) {
+	if initial != nil {
+		initial.setRef(1)
+		c.pool.adopt(initial)
+	}
+
 	c.mu.Lock()
-	oldObj := c.current
-	c.current = newObj
+	if c.status != StatusActive {
+		c.mu.Unlock()
+		c.pool.Release(initial)
+
+		return
+	}
+	oldObj, spare := c.current, c.spare
+	c.current, c.spare = initial, nil
+	c.peak.Store(0)
+	c.gen = 0
+	hist := c.clearHistoryLocked()
 	c.mu.Unlock()
 
+	for _, obj := range hist {
+		c.retire(obj)
+	}
+
 	if oldObj != nil {
-		c.pool.Release(oldObj)
+		c.retire(oldObj)
+	}
+	if spare != nil {
+		c.retire(spare)
+	}
+	c.notify()
+}
+
+// Detach removes the current object from the container and returns it along with
+// the container's reference, transferring ownership to the caller (who must Release it
+// eventually, or install it elsewhere). Use it for teardown and migration flows.
+//
+// Afterwards the container is empty, as after Update(nil): Acquire returns nil until
+// the next Update, and the generation advances. Readers holding references to the
+// detached object are unaffected, and the object is not tracked as retired.
+//
+// Returns ErrNoCurrent if the container is empty, ErrClosed if it is closed.
+func (c *Container[T, PT]) Detach() (*T, error) {
+	c.mu.Lock()
+	if c.status != StatusActive {
+		c.mu.Unlock()
+
+		return nil, ErrClosed
+	}
+	obj := c.current
+	if obj == nil {
+		c.mu.Unlock()
+
+		return nil, ErrNoCurrent
 	}
+	c.current = nil
+	c.peak.Store(0)
+	c.gen++
+	c.mu.Unlock()
+
+	c.notify()
+
+	return obj, nil
+}
+
+// Fork creates an independent container over the same pool, starting out with the
+// current object of c (or empty, if c is). The fork holds its own reference to that
+// object, so it is not retired until both containers have replaced it and its readers
+// have released it. From then on the two evolve independently: Updates to one do not
+// affect the other. The fork's generations start at 0, and opts configure it (options
+// of c are not inherited).
+func (c *Container[T, PT]) Fork(opts ...ContainerOption) *Container[T, PT] {
+	return newContainer(c.pool, PT(c.Acquire()), opts)
 }
 
 // Release is a convenience proxy to the underlying Pool's Release.
@@ -155,6 +734,11 @@ func (c *Container[T, PT]) Release(obj *T) {
 	c.pool.Release(obj)
 }
 
+// ReleaseAll is a convenience proxy to the underlying Pool's ReleaseAll.
+func (c *Container[T, PT]) ReleaseAll(objs []*T) {
+	c.pool.ReleaseAll(objs)
+}
+
 // GetNew is a convenience proxy to the underlying Pool's Get.
 func (c *Container[T, PT]) GetNew() *T {
 	return c.pool.Get()
@@ -163,19 +747,51 @@ func (c *Container[T, PT]) GetNew() *T {
 // Acquire returns the current active object with its reference count incremented.
 // The caller owns this reference and must call Release() when finished.
 //
+// Acquire is lock-based: it takes the read lock and does a single atomic add, waiting
+// only while Updates swap the pointer, so the wait is bounded by the number of queued
+// Updates (see WithAcquireSpin). It returns nil when the container is closing or closed.
+//
 // Returns nil if the container is empty.
 func (c *Container[T, PT]) Acquire() *T {
-	c.mu.RLock()
+	c.rlock()
 	obj := c.current
 	// check for nil in case the container hasn't been initialized yet
+	var refs int64
 	if obj != nil {
-		obj.addRef(1)
+		refs = obj.addRef(1)
+		c.notePeak(refs)
 	}
 	c.mu.RUnlock()
+	if obj != nil {
+		c.checkMaxRefs(refs)
+		c.pool.acquired(refs)
+	}
 
 	return obj
 }
 
+// rlock takes the read lock, spinning first if configured via WithAcquireSpin.
+func (c *Container[T, PT]) rlock() {
+	for range c.spin {
+		if c.mu.TryRLock() {
+			return
+		}
+	}
+	c.mu.RLock()
+}
+
+// CurrentHasReaders reports whether anyone other than the container holds a
+// reference to the current object. Returns false if the container is empty.
+//
+// This is an approximate, racy hint (readers may come and go right after it returns),
+// e.g. for a writer deciding whether an expensive Update is worth doing now.
+func (c *Container[T, PT]) CurrentHasReaders() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.current != nil && c.current.refs() > 1
+}
+
 // WithAcquire is a helper that executes fn with the current object (can be nil) and
 // automatically releases it afterwards.
 func (c *Container[T, PT]) WithAcquire(fn func(obj *T)) {
@@ -185,3 +801,73 @@ func (c *Container[T, PT]) WithAcquire(fn func(obj *T)) {
 	}
 	fn(obj)
 }
+
+// Inspect runs fn with the current object, holding a reference for the duration of fn,
+// so the object is guaranteed not to be reset or reused while fn reads it.
+// This makes it the safest way to read several fields consistently.
+//
+// Unlike WithAcquire, fn is not called if the container is empty; Inspect reports
+// whether fn ran. The reference is released even if fn panics.
+func (c *Container[T, PT]) Inspect(fn func(obj *T)) bool {
+	obj := c.Acquire()
+	if obj == nil {
+		return false
+	}
+	defer c.Release(obj)
+	fn(obj)
+
+	return true
+}
+
+// Recycle drops every object the pool currently holds and makes every object
+// currently outstanding be discarded (after Reset) when its last reference is released,
+// instead of being pooled again. Subsequent Gets construct fresh objects.
+// Use it to roll out a new object layout (e.g. after changing the factory) without
+// disturbing live containers: their current objects stay valid until replaced.
+//
+// Idle objects are dropped lazily: Get skips them, and the GC collects them.
+func (p *Pool[T, PT]) Recycle() {
+	p.epoch.Add(1)
+	if p.affinity != nil {
+		p.affinity.drain()
+	}
+	if p.idle != nil {
+		p.idle.drain()
+	}
+}
+
+// SetNewFunc replaces the pool's factory: objects allocated from now on are built by fn,
+// while pooled objects built by the old factory keep being served. Call Recycle as well
+// to flush those instead. A nil fn stops the pool from allocating, as for NewPool with a
+// nil factory. Safe for concurrent use with Get: each allocation uses either the old
+// or the new factory.
+//
+// Child pools created by NewChildPool keep the factory they were created with.
+func (p *Pool[T, PT]) SetNewFunc(fn func() *T) {
+	if fn == nil {
+		p.factory.Store(nil)
+
+		return
+	}
+	p.factory.Store(&fn)
+}
+
+// newFunc returns the current factory, or nil if there is none.
+func (p *Pool[T, PT]) newFunc() func() *T {
+	if fn := p.factory.Load(); fn != nil {
+		return *fn
+	}
+
+	return nil
+}
+
+// currentEpoch returns the epoch stamp for objects that belong to the pool as of now.
+// Stamps are offset by one so that 0 can mean "unknown" (e.g. objects not created by the pool).
+func (p *Pool[T, PT]) currentEpoch() uint64 {
+	return p.epoch.Load() + 1
+}
+
+// stale reports whether obj belongs to an epoch before the last Recycle.
+func (p *Pool[T, PT]) stale(obj *T) bool {
+	return PT(obj).epoch() != p.currentEpoch()
+}
@@ -5,10 +5,12 @@ import (
 	"math/rand"
 	"os"
 	"runtime"
+	"slices"
 	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/keilerkonzept/poolswap"
 )
@@ -211,3 +213,190 @@ func BenchmarkHotSwap(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkAcquireRelease isolates the cost of an Acquire/Release pair,
+// comparing the embedded Ref against ExternalContainer's sync.Map refcounts.
+func BenchmarkAcquireRelease(b *testing.B) {
+	b.Run("impl=Container", func(b *testing.B) {
+		p := poolswap.NewPool(
+			func() *Heavy { return &Heavy{} },
+			func(h *Heavy) bool { return h.reset() },
+		)
+		c := poolswap.NewContainer(p, p.Get())
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				c.Release(c.Acquire())
+			}
+		})
+	})
+	b.Run("impl=ExternalContainer", func(b *testing.B) {
+		c := poolswap.NewExternalContainer(
+			func() *Heavy { return &Heavy{} },
+			func(h *Heavy) bool { return h.reset() },
+		)
+		c.Update(c.GetNew())
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				c.Release(c.Acquire())
+			}
+		})
+	})
+}
+
+// BenchmarkAcquireSpin measures Acquire/Release against concurrent Updates
+// (no read work, so Acquire and Update contend as much as possible),
+// with and without WithAcquireSpin.
+func BenchmarkAcquireSpin(b *testing.B) {
+	for _, writes := range []int{10, 50} {
+		for _, spin := range []int{0, 4, 16, 64} {
+			b.Run(fmt.Sprintf("writes=%02d/spin=%d", writes, spin), func(b *testing.B) {
+				p := poolswap.NewPool(
+					func() *Heavy { return &Heavy{} },
+					func(_ *Heavy) bool { return true },
+				)
+				c := poolswap.NewContainer(p, p.Get(), poolswap.WithAcquireSpin(spin))
+				b.ReportAllocs()
+				b.RunParallel(func(pb *testing.PB) {
+					iter := 0
+					for pb.Next() {
+						iter++
+						if iter%100 < writes {
+							c.Update(c.GetNew())
+						} else {
+							c.Release(c.Acquire())
+						}
+					}
+				})
+			})
+		}
+	}
+}
+
+// BenchmarkAcquireInto checks that AcquireInto with a stack-allocated Guard stays at 0 allocs/op.
+func BenchmarkAcquireInto(b *testing.B) {
+	p := poolswap.NewPool(
+		func() *Heavy { return &Heavy{} },
+		func(h *Heavy) bool { return h.reset() },
+	)
+	c := poolswap.NewContainer(p, p.Get())
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			var g poolswap.Guard[Heavy, *Heavy]
+			obj := c.AcquireInto(&g)
+			_ = obj.Data
+			g.Release()
+		}
+	})
+}
+
+// BenchmarkAcquireLatency measures per-call Acquire latency while one goroutine
+// runs Updates back to back (maximal writer contention), reporting the worst case
+// and the 99.9th percentile alongside the mean.
+func BenchmarkAcquireLatency(b *testing.B) {
+	p := poolswap.NewPool(
+		func() *Heavy { return &Heavy{} },
+		func(_ *Heavy) bool { return true },
+	)
+	c := poolswap.NewContainer(p, p.Get())
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				c.Update(c.GetNew())
+			}
+		}
+	})
+
+	var mu sync.Mutex
+	var all []time.Duration
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		local := make([]time.Duration, 0, 1024)
+		for pb.Next() {
+			start := time.Now()
+			obj := c.Acquire()
+			local = append(local, time.Since(start))
+			c.Release(obj)
+		}
+		mu.Lock()
+		all = append(all, local...)
+		mu.Unlock()
+	})
+	b.StopTimer()
+	close(done)
+	wg.Wait()
+
+	if len(all) == 0 {
+		return
+	}
+	slices.Sort(all)
+	b.ReportMetric(float64(all[len(all)-1].Nanoseconds()), "max-ns")
+	b.ReportMetric(float64(all[len(all)*999/1000].Nanoseconds()), "p99.9-ns")
+}
+
+// BenchmarkResetOnGet compares reader-side Release latency with Reset running on
+// the drain path (default) versus on Get (WithResetOnGet). With the default, the
+// reader dropping the last reference to a displaced object pays for clearing it.
+func BenchmarkResetOnGet(b *testing.B) {
+	setupPrecomputedData()
+	modes := []struct {
+		name string
+		opts []poolswap.PoolOption
+	}{
+		{"reset=drain", nil},
+		{"reset=get", []poolswap.PoolOption{poolswap.WithResetOnGet()}},
+	}
+	for _, mode := range modes {
+		b.Run(mode.name, func(b *testing.B) {
+			p := poolswap.NewPool(
+				func() *Heavy { return &Heavy{} },
+				func(h *Heavy) bool { return h.reset() },
+				mode.opts...,
+			)
+			initObj := p.Get()
+			initObj.simulateFill()
+			c := poolswap.NewContainer(p, initObj)
+
+			var mu sync.Mutex
+			var releases []time.Duration
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				local := make([]time.Duration, 0, 1024)
+				iter := 0
+				for pb.Next() {
+					iter++
+					if iter%100 < 10 {
+						newObj := c.GetNew()
+						newObj.simulateFill()
+						c.Update(newObj)
+					} else {
+						obj := c.Acquire()
+						obj.simulateRead()
+						start := time.Now()
+						c.Release(obj)
+						local = append(local, time.Since(start))
+					}
+				}
+				mu.Lock()
+				releases = append(releases, local...)
+				mu.Unlock()
+			})
+
+			if len(releases) == 0 {
+				return
+			}
+			slices.Sort(releases)
+			b.ReportMetric(float64(releases[len(releases)*99/100].Nanoseconds()), "release-p99-ns")
+			b.ReportMetric(float64(releases[len(releases)*9999/10000].Nanoseconds()), "release-p99.99-ns")
+			b.ReportMetric(float64(releases[len(releases)-1].Nanoseconds()), "release-max-ns")
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package poolswap_test
 
 import (
+	"errors"
+	"math/rand/v2"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -220,3 +222,475 @@ func TestProp_Refcounts(t *testing.T) {
 		})
 	})
 }
+
+func TestCurrentHasReaders(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewEmptyContainer(pool)
+
+	if container.CurrentHasReaders() {
+		t.Fatal("empty container should report no readers")
+	}
+
+	container.Update(pool.Get())
+	if container.CurrentHasReaders() {
+		t.Fatal("container's own reference should not count as a reader")
+	}
+
+	obj := container.Acquire()
+	if !container.CurrentHasReaders() {
+		t.Fatal("should report readers while a reference is held")
+	}
+
+	container.Release(obj)
+	if container.CurrentHasReaders() {
+		t.Fatal("should report no readers after release")
+	}
+}
+
+func TestPool_NilFactory(t *testing.T) {
+	pool := poolswap.NewPool[MockPayload, *MockPayload](nil, func(obj *MockPayload) bool {
+		obj.Recycled.Store(true)
+		return true
+	})
+
+	if obj := pool.Get(); obj != nil {
+		t.Fatal("pool without factory should return nil when empty")
+	}
+	if s := pool.Stats(); s.News != 0 || s.Gets != 0 {
+		t.Fatalf("nil Get should not count as a Get or New: %+v", s)
+	}
+
+	// sync.Pool may drop objects (e.g. under the race detector), so feed a few.
+	fed := make(map[*MockPayload]bool)
+	for range 20 {
+		obj := &MockPayload{}
+		fed[obj] = true
+		pool.Put(obj)
+	}
+
+	obj := pool.Get()
+	if obj == nil {
+		t.Fatal("pool should recycle objects fed via Put")
+	}
+	if !fed[obj] || !obj.Recycled.Load() {
+		t.Fatal("Get should return a Put object that was cleaned via Reset")
+	}
+	if obj.DebugPeekRef() != 1 {
+		t.Fatalf("recycled object should have Ref=1, got %d", obj.DebugPeekRef())
+	}
+
+	container := poolswap.NewContainer(pool, obj)
+	container.Update(nil)
+	if container.Acquire() != nil {
+		t.Fatal("Update(nil) should empty the container")
+	}
+}
+
+func TestInspect(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewEmptyContainer(pool)
+
+	if container.Inspect(func(_ *MockPayload) { t.Fatal("fn must not run on an empty container") }) {
+		t.Fatal("Inspect on an empty container should report false")
+	}
+
+	obj := pool.Get()
+	container.Update(obj)
+
+	ran := container.Inspect(func(got *MockPayload) {
+		if got != obj {
+			t.Fatal("Inspect should pass the current object")
+		}
+		if got.DebugPeekRef() != 2 {
+			t.Fatalf("want Ref=2 during Inspect, got %d", got.DebugPeekRef())
+		}
+	})
+	if !ran {
+		t.Fatal("Inspect should report true when fn ran")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("panic should propagate out of Inspect")
+			}
+		}()
+		container.Inspect(func(_ *MockPayload) { panic("boom") })
+	}()
+
+	if obj.DebugPeekRef() != 1 {
+		t.Fatalf("reference should be released on panic, got Ref=%d", obj.DebugPeekRef())
+	}
+}
+
+func TestAcquireSpin(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get(), poolswap.WithAcquireSpin(8))
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Go(func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				container.Update(pool.Get())
+			}
+		}
+	})
+	for range 1000 {
+		obj := container.Acquire()
+		if obj == nil || obj.DebugPeekRef() < 1 {
+			t.Fatal("Acquire with spinning should return a referenced object")
+		}
+		container.Release(obj)
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestNew(t *testing.T) {
+	var resets atomic.Int64
+	factory := func() *MockPayload { return &MockPayload{} }
+	reset := func(_ *MockPayload) bool { resets.Add(1); return true }
+
+	container := poolswap.New(factory, reset)
+	first := container.Acquire()
+	if first == nil {
+		t.Fatal("New without initial should start with a fresh pooled object")
+	}
+	container.Release(first)
+	if container.Pool() == nil || container.Pool().Stats().Gets != 1 {
+		t.Fatal("the initial object should come from the container's pool")
+	}
+
+	next := container.GetNew()
+	container.Update(next)
+	if resets.Load() != 1 {
+		t.Fatalf("displaced initial object should be reset, resets=%d", resets.Load())
+	}
+	container.WithAcquire(func(obj *MockPayload) {
+		if obj != next {
+			t.Fatal("Update should install the new object")
+		}
+	})
+
+	initial := &MockPayload{ID: 42}
+	withInitial := poolswap.New(factory, reset, initial)
+	withInitial.WithAcquire(func(obj *MockPayload) {
+		if obj != initial || obj.DebugPeekRef() != 2 {
+			t.Fatalf("New should install the given initial object, got %+v", obj)
+		}
+	})
+}
+
+var errInvalid = errors.New("invalid")
+
+func TestUpdateValidated(t *testing.T) {
+	pool := newMockPool()
+	current := pool.Get()
+	container := poolswap.NewContainer(pool, current)
+
+	invalid := pool.Get()
+	invalid.Recycled.Store(false)
+	err := container.UpdateValidated(invalid, func(obj *MockPayload) error {
+		if obj != invalid {
+			t.Fatal("validate should receive the candidate object")
+		}
+		return errInvalid
+	})
+	if !errors.Is(err, errInvalid) {
+		t.Fatalf("want validation error, got %v", err)
+	}
+	if !invalid.Recycled.Load() {
+		t.Fatal("rejected object should be returned to the pool")
+	}
+	container.WithAcquire(func(obj *MockPayload) {
+		if obj != current {
+			t.Fatal("current object should be unchanged after a rejected update")
+		}
+	})
+	if container.Generation() != 0 {
+		t.Fatal("a rejected update must not advance the generation")
+	}
+
+	valid := pool.Get()
+	err = container.UpdateValidated(valid, func(_ *MockPayload) error { return nil })
+	if err != nil {
+		t.Fatalf("valid update failed: %v", err)
+	}
+	container.WithAcquire(func(obj *MockPayload) {
+		if obj != valid {
+			t.Fatal("valid object should be installed")
+		}
+	})
+
+	container.Close()
+	err = container.UpdateValidated(pool.Get(), func(_ *MockPayload) error { return nil })
+	if !errors.Is(err, poolswap.ErrClosed) {
+		t.Fatalf("want ErrClosed, got %v", err)
+	}
+}
+
+func TestPool_SetNewFunc(t *testing.T) {
+	pool := newBoundedPool(poolswap.WithMaxIdle(4))
+	old := pool.Get()
+	old.ID = 1
+	pool.Release(old)
+
+	pool.SetNewFunc(func() *MockPayload { return &MockPayload{ID: 2} })
+	if got := pool.Get(); got != old {
+		t.Fatal("pooled objects from the old factory should still be served")
+	}
+	if got := pool.Get(); got.ID != 2 {
+		t.Fatalf("allocations should use the new factory, got ID %d", got.ID)
+	}
+
+	pool.SetNewFunc(nil)
+	if got := pool.Get(); got != nil {
+		t.Fatal("a nil factory should stop the pool from allocating")
+	}
+}
+
+func TestPool_Recycle(t *testing.T) {
+	pool := newMockPool()
+
+	idle := make(map[*MockPayload]bool)
+	for range 10 {
+		obj := pool.Get()
+		idle[obj] = true
+	}
+	for obj := range idle {
+		pool.Release(obj)
+	}
+
+	outstanding := pool.Get()
+	outstanding.Recycled.Store(false)
+	container := poolswap.NewContainer(pool, outstanding)
+	_ = pool.StatsAndReset()
+
+	pool.Recycle()
+
+	for range 10 {
+		obj := pool.Get()
+		if idle[obj] || obj == outstanding {
+			t.Fatal("Get after Recycle must not return an object pooled before it")
+		}
+	}
+	if s := pool.Stats(); s.News != 10 {
+		t.Fatalf("Gets after Recycle should construct fresh objects, News=%d", s.News)
+	}
+
+	_ = pool.StatsAndReset()
+	container.Update(pool.Get()) // drains the pre-Recycle object
+	if !outstanding.Recycled.Load() {
+		t.Fatal("outstanding object should still be reset when released")
+	}
+	if s := pool.Stats(); s.Discards != 1 || s.Puts != 0 {
+		t.Fatalf("outstanding object should be discarded on release, got %+v", s)
+	}
+}
+
+func TestPool_Recycle_Adopted(t *testing.T) {
+	pool := newMockPool()
+	adopted := &MockPayload{}
+	container := poolswap.NewContainer(pool, adopted)
+	reset := &MockPayload{}
+
+	pool.Recycle()
+	container.Update(pool.Get()) // drains the adopted object, outstanding at Recycle
+	if s := pool.Stats(); s.Discards != 1 || s.Puts != 0 {
+		t.Fatalf("an adopted object outstanding at Recycle should be discarded, got %+v", s)
+	}
+
+	container.Reset(reset)
+	_ = pool.StatsAndReset()
+	pool.Recycle()
+	container.Update(pool.Get())
+	if s := pool.Stats(); s.Discards != 1 || s.Puts != 0 {
+		t.Fatalf("an object adopted via Reset should be discarded too, got %+v", s)
+	}
+	if !adopted.Recycled.Load() || !reset.Recycled.Load() {
+		t.Fatal("adopted objects should still be reset when released")
+	}
+}
+
+func TestDetach(t *testing.T) {
+	pool := newMockPool()
+	obj := pool.Get()
+	obj.Recycled.Store(false)
+	container := poolswap.NewContainer(pool, obj)
+
+	detached, err := container.Detach()
+	if err != nil || detached != obj {
+		t.Fatalf("Detach should return the current object, got %p, %v", detached, err)
+	}
+	if detached.DebugPeekRef() != 1 {
+		t.Fatalf("caller should own the container's reference, Ref=%d", detached.DebugPeekRef())
+	}
+	if container.Acquire() != nil {
+		t.Fatal("Acquire after Detach should return nil until the next Update")
+	}
+	_, err = container.Detach()
+	if !errors.Is(err, poolswap.ErrNoCurrent) {
+		t.Fatalf("Detach on an empty container: want ErrNoCurrent, got %v", err)
+	}
+
+	next := pool.Get()
+	container.Update(next)
+	if got := container.Acquire(); got != next {
+		t.Fatal("Update after Detach should install the new object")
+	} else {
+		container.Release(got)
+	}
+	if detached.Recycled.Load() || container.RetiredCount() != 0 {
+		t.Fatal("detached object belongs to the caller, not the container")
+	}
+
+	pool.Release(detached)
+	if !detached.Recycled.Load() {
+		t.Fatal("releasing the detached object should return it to the pool")
+	}
+
+	container.Close()
+	_, err = container.Detach()
+	if !errors.Is(err, poolswap.ErrClosed) {
+		t.Fatalf("Detach on a closed container: want ErrClosed, got %v", err)
+	}
+}
+
+func TestReleaseAll(t *testing.T) {
+	pool := newMockPool()
+	a, b := pool.Get(), pool.Get()
+	a.Recycled.Store(false)
+	b.Recycled.Store(false)
+	container := poolswap.NewContainer(pool, a)
+
+	held := make([]*MockPayload, 0, 6)
+	for range 3 {
+		held = append(held, container.Acquire()) // a: Ref=4
+	}
+	container.Update(b) // a: Ref=3, owned only by the readers
+	for range 2 {
+		held = append(held, container.Acquire()) // b: Ref=3
+	}
+	held = append(held, nil)
+
+	_ = pool.StatsAndReset()
+	container.ReleaseAll(held)
+
+	if !a.Recycled.Load() {
+		t.Fatal("a dropped to zero and should be returned to the pool")
+	}
+	if b.Recycled.Load() || b.DebugPeekRef() != 1 {
+		t.Fatalf("b is still current and must not be pooled, Ref=%d", b.DebugPeekRef())
+	}
+	if s := pool.Stats(); s.Puts != 1 {
+		t.Fatalf("exactly one object should be pooled, got %+v", s)
+	}
+}
+
+func TestUpdate_RetiresToOwningPool(t *testing.T) {
+	poolA, poolB := newMockPool(), newMockPool()
+	a := poolA.Get()
+	container := poolswap.NewContainer(poolA, a)
+
+	reader := container.Acquire()
+	container.Update(poolB.Get()) // migrate: new objects come from B
+
+	current := container.Acquire()
+	container.Release(current)
+	container.Release(reader) // a drains via the container, whose pool is A
+
+	if s := poolA.Stats(); s.Puts != 1 {
+		t.Fatalf("a should return to pool A, got %+v", s)
+	}
+
+	b, _ := container.Detach()
+	poolA.Release(b) // released via A, but b belongs to B
+
+	if s := poolB.Stats(); s.Puts != 1 {
+		t.Fatalf("b should return to pool B, got %+v", s)
+	}
+	if s := poolA.Stats(); s.Puts != 1 || s.Discards != 0 {
+		t.Fatalf("pool A should not receive b, got %+v", s)
+	}
+}
+
+func TestSwapIfNewer(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewEmptyContainer(pool)
+	older := func(cur, newObj *MockPayload) bool { return cur.ID < newObj.ID }
+
+	objs := make([]*MockPayload, 50)
+	for i := range objs {
+		objs[i] = pool.Get()
+		objs[i].ID = int64(i + 1)
+	}
+
+	var wg sync.WaitGroup
+	var installed atomic.Int64
+	for _, i := range rand.Perm(len(objs)) {
+		wg.Go(func() {
+			if container.SwapIfNewer(objs[i], older) {
+				installed.Add(1)
+			}
+		})
+	}
+	wg.Wait()
+
+	cur := container.Acquire()
+	defer container.Release(cur)
+	if cur.ID != int64(len(objs)) {
+		t.Fatalf("the newest object should win, got ID %d", cur.ID)
+	}
+	if installed.Load() < 1 || uint64(installed.Load()) != container.Generation() {
+		t.Fatalf("each successful swap should install once: %d swaps, generation %d",
+			installed.Load(), container.Generation())
+	}
+
+	stale := pool.Get()
+	stale.ID = 1
+	if container.SwapIfNewer(stale, older) {
+		t.Fatal("an older object must be rejected")
+	}
+	if stale.DebugPeekRef() != 0 {
+		t.Fatalf("a rejected object should be released, Ref=%d", stale.DebugPeekRef())
+	}
+}
+
+func TestFork(t *testing.T) {
+	pool := newMockPool()
+	obj := pool.Get()
+	obj.Recycled.Store(false)
+	original := poolswap.NewContainer(pool, obj)
+
+	fork := original.Fork()
+	if obj.DebugPeekRef() != 2 {
+		t.Fatalf("the fork should hold its own reference, Ref=%d", obj.DebugPeekRef())
+	}
+	if fork.Pool() != pool {
+		t.Fatal("the fork should share the pool")
+	}
+
+	fork.Update(pool.Get())
+	current := original.Acquire()
+	if current != obj {
+		t.Fatal("updating the fork must not change the original")
+	}
+	original.Release(current)
+	if obj.Recycled.Load() {
+		t.Fatal("obj is still current in the original and must not be recycled")
+	}
+
+	original.Update(pool.Get())
+	if !obj.Recycled.Load() {
+		t.Fatal("obj should be recycled once both containers replaced it")
+	}
+
+	empty := poolswap.NewEmptyContainer(pool).Fork()
+	if empty.Acquire() != nil {
+		t.Fatal("a fork of an empty container should be empty")
+	}
+}
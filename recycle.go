@@ -0,0 +1,86 @@
+package poolswap
+
+// RecycleInto installs a new current object built by build, reusing the object
+// displaced by the previous RecycleInto call if all its readers have released it.
+// This maximizes reuse for two-generation (ping-pong) writers: the same two
+// instances alternate instead of round-tripping through the pool, which might
+// hand back a different (cold) object.
+//
+// The reused object is flushed (see WithOnRetireSync) and cleaned via the pool's Reset
+// before build is called.
+// If there is no such object yet, readers still hold it, it belongs to another pool,
+// or the flush or Reset fails, a fresh object from the pool is used instead.
+//
+// The object displaced by RecycleInto is kept by the container (holding a
+// reference, so it does not count as retired) until the next RecycleInto.
+// Returns the generation assigned to the new object, or 0 if the container is closed.
+// If there is no object to build into (the pool has no factory and nothing to recycle),
+// build is not called, the current object stays in place, and RecycleInto returns 0.
+func (c *Container[T, PT]) RecycleInto(build func(obj *T)) uint64 {
+	c.mu.Lock()
+	obj := c.spare
+	c.spare = nil
+	c.mu.Unlock()
+
+	obj = c.reclaim(obj)
+	if obj == nil {
+		obj = c.pool.Get()
+	}
+	if obj == nil { // no factory and nothing to recycle
+		return 0
+	}
+	build(obj)
+
+	c.mu.Lock()
+	if c.status != StatusActive {
+		c.mu.Unlock()
+		c.pool.Release(obj)
+
+		return 0
+	}
+	oldObj := c.current
+	evicted := c.recordLocked(oldObj, c.gen)
+	c.current = obj
+	c.peak.Store(0)
+	c.gen++
+	gen := c.gen
+	prev := c.spare
+	c.spare = oldObj
+	c.mu.Unlock()
+
+	if evicted != nil {
+		c.retire(evicted)
+	}
+
+	if prev != nil { // a concurrent RecycleInto left one too
+		c.retire(prev)
+	}
+	c.notify()
+
+	return gen
+}
+
+// reclaim returns the displaced spare obj, issued anew, if it can be rebuilt in place.
+// Otherwise it retires or discards obj and returns nil.
+func (c *Container[T, PT]) reclaim(obj *T) *T {
+	// A displaced object can no longer be acquired, so once only our
+	// reference is left, nobody else can get hold of it any more.
+	switch {
+	case obj == nil:
+		return nil
+	case PT(obj).refs() != 1, c.pool.stale(obj), c.pool.ownerOf(obj) != c.pool:
+		c.retire(obj)
+
+		return nil
+	case !c.pool.flushed(obj), !c.pool.reset(obj):
+		c.pool.stats.discards.Add(1)
+		if c.pool.takeBack(obj) {
+			c.pool.checkIn()
+		}
+
+		return nil
+	}
+	PT(obj).issue()
+
+	return obj
+}
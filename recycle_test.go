@@ -0,0 +1,78 @@
+package poolswap_test
+
+import (
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestRecycleInto_PingPong(t *testing.T) {
+	pool := newMockPool()
+	initial := pool.Get()
+	container := poolswap.NewContainer(pool, initial)
+
+	seen := make(map[*MockPayload]int)
+	for i := range 10 {
+		container.RecycleInto(func(obj *MockPayload) {
+			obj.ID = int64(i)
+			obj.Content = append(obj.Content, byte(i))
+		})
+		container.WithAcquire(func(obj *MockPayload) {
+			if obj.ID != int64(i) || len(obj.Content) != 1 {
+				t.Fatalf("cycle %d: object not rebuilt from a clean state: %+v", i, obj)
+			}
+			seen[obj]++
+		})
+	}
+
+	if len(seen) != 2 {
+		t.Fatalf("want 2 alternating instances, got %d", len(seen))
+	}
+	if seen[initial] != 5 {
+		t.Fatalf("initial object should be reused every other cycle, got %d", seen[initial])
+	}
+}
+
+func TestRecycleInto_HeldSpareIsNotReused(t *testing.T) {
+	pool := newMockPool()
+	initial := pool.Get()
+	initial.Recycled.Store(false)
+	container := poolswap.NewContainer(pool, initial)
+
+	held := container.Acquire()
+	container.RecycleInto(func(_ *MockPayload) {})
+	container.RecycleInto(func(obj *MockPayload) {
+		if obj == initial {
+			t.Fatal("object still held by a reader must not be reused")
+		}
+	})
+
+	if got := container.RetiredCount(); got != 1 {
+		t.Fatalf("held object should be tracked as retired, got %d", got)
+	}
+	if held.Recycled.Load() {
+		t.Fatal("held object must not be reset")
+	}
+	container.Release(held)
+	if !held.Recycled.Load() {
+		t.Fatal("object should be returned to the pool once released")
+	}
+}
+
+func TestRecycleInto_NoObject(t *testing.T) {
+	pool := poolswap.NewPool[MockPayload](nil, func(*MockPayload) bool { return true })
+	current := new(MockPayload)
+	container := poolswap.NewContainer(pool, current)
+
+	gen := container.RecycleInto(func(*MockPayload) {
+		t.Fatal("build must not be called without an object")
+	})
+	if gen != 0 {
+		t.Fatalf("want generation 0, got %d", gen)
+	}
+	got := container.Acquire()
+	defer container.Release(got)
+	if got != current || container.Generation() != 0 {
+		t.Fatal("the current object should stay in place")
+	}
+}
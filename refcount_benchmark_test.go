@@ -0,0 +1,165 @@
+package poolswap_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+// Alternative reference counting schemes, benchmarked against the one the package
+// uses (a single atomic counter per object, behind the container's read lock).
+// They isolate the counter: acquire and release of one object that is never swapped.
+//
+// Neither alternative is safe to retire without extra machinery that the benchmark
+// leaves out: a sharded counter can only be summed exactly while no worker runs
+// (percpu-refcount switches to a single atomic first), and a biased counter's owner
+// half may only be read after a handshake with the owning goroutine.
+type refScheme interface {
+	acquire(worker int)
+	release(worker int)
+	// drained reports whether all references were released. Only valid while no worker runs.
+	drained() bool
+}
+
+// singleRef is one shared atomic counter, as in poolswap.Ref.
+type singleRef struct {
+	count atomic.Int64
+}
+
+func (r *singleRef) acquire(int)   { r.count.Add(1) }
+func (r *singleRef) release(int)   { r.count.Add(-1) }
+func (r *singleRef) drained() bool { return r.count.Load() == 0 }
+
+// shardedRef spreads the count over cache-line padded shards, one per worker (modulo).
+// Acquire and release never share a cache line across workers; reading the total costs a sum.
+type shardedRef struct {
+	shards []paddedCount
+}
+
+type paddedCount struct {
+	n atomic.Int64
+	_ [56]byte
+}
+
+func newShardedRef(shards int) *shardedRef {
+	return &shardedRef{shards: make([]paddedCount, shards)}
+}
+
+func (r *shardedRef) acquire(worker int) { r.shards[worker%len(r.shards)].n.Add(1) }
+func (r *shardedRef) release(worker int) { r.shards[worker%len(r.shards)].n.Add(-1) }
+
+func (r *shardedRef) drained() bool {
+	var sum int64
+	for i := range r.shards {
+		sum += r.shards[i].n.Load()
+	}
+
+	return sum == 0
+}
+
+// biasedRef gives one owner goroutine (worker 0) a plain, non-atomic counter and
+// makes everyone else share an atomic one, as in biased reference counting (Choi et al.).
+// It wins when one goroutine does most of the acquiring.
+type biasedRef struct {
+	owned  int64 // only touched by worker 0
+	_      [56]byte
+	shared atomic.Int64
+}
+
+func (r *biasedRef) acquire(worker int) {
+	if worker == 0 {
+		r.owned++
+
+		return
+	}
+	r.shared.Add(1)
+}
+
+func (r *biasedRef) release(worker int) {
+	if worker == 0 {
+		r.owned--
+
+		return
+	}
+	r.shared.Add(-1)
+}
+
+func (r *biasedRef) drained() bool { return r.owned+r.shared.Load() == 0 }
+
+// containerRef is the package as shipped: Container.Acquire/Release of a fixed object,
+// i.e. a single counter plus the container's read lock.
+type containerRef struct {
+	c   *poolswap.Container[Heavy, *Heavy]
+	obj *Heavy
+}
+
+func newContainerRef() *containerRef {
+	p := poolswap.NewPool(
+		func() *Heavy { return &Heavy{} },
+		func(h *Heavy) bool { return h.reset() },
+	)
+	obj := p.Get()
+
+	return &containerRef{c: poolswap.NewContainer(p, obj), obj: obj}
+}
+
+func (r *containerRef) acquire(int)   { r.c.Acquire() }
+func (r *containerRef) release(int)   { r.c.Release(r.obj) }
+func (r *containerRef) drained() bool { return r.obj.DebugPeekRef() == 1 } // only the container's own
+
+var refSchemes = []struct {
+	name string
+	new  func() refScheme
+}{
+	{"container", func() refScheme { return newContainerRef() }},
+	{"single", func() refScheme { return new(singleRef) }},
+	{"sharded", func() refScheme { return newShardedRef(64) }},
+	{"biased", func() refScheme { return new(biasedRef) }},
+}
+
+// runRefScheme runs ops acquire/release pairs split across the given number of goroutines.
+func runRefScheme(r refScheme, goroutines, ops int) {
+	var wg sync.WaitGroup
+	per := ops / goroutines
+	for w := range goroutines {
+		n := per
+		if w == 0 {
+			n += ops % goroutines
+		}
+		wg.Go(func() {
+			for range n {
+				r.acquire(w)
+				r.release(w)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestRefSchemes(t *testing.T) {
+	for _, s := range refSchemes {
+		r := s.new()
+		runRefScheme(r, 8, 10_000)
+		if !r.drained() {
+			t.Fatalf("%s: balanced acquire/release should leave no references", s.name)
+		}
+	}
+}
+
+// BenchmarkRefSchemes measures the cost of one acquire/release pair per scheme,
+// at increasing numbers of goroutines contending for the same object.
+func BenchmarkRefSchemes(b *testing.B) {
+	for _, s := range refSchemes {
+		for _, goroutines := range []int{1, 4, 16, 64} {
+			b.Run(fmt.Sprintf("scheme=%s/goroutines=%02d", s.name, goroutines), func(b *testing.B) {
+				r := s.new()
+				b.ReportAllocs()
+				b.ResetTimer()
+				runRefScheme(r, goroutines, b.N)
+			})
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package poolswap
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// retiredList tracks objects that were swapped out of a Container but may
+// still be held by readers.
+//
+// Drains happen through Pool.Release, which the container does not observe,
+// so entries are pruned lazily: an entry is stale once its object's reference
+// count has dropped to zero, or once the object has been handed out again
+// (its life counter moved on).
+type retiredList[T any, PT PtrRef[T]] struct {
+	mu      sync.Mutex
+	entries []retiredEntry[T]
+}
+
+type retiredEntry[T any] struct {
+	obj  *T
+	life uint64
+	at   time.Time
+}
+
+func newRetiredList[T any, PT PtrRef[T]]() retiredList[T, PT] {
+	return retiredList[T, PT]{
+		mu:      sync.Mutex{},
+		entries: nil,
+	}
+}
+
+// live reports the entry's outstanding reference count, or false if it is stale.
+// The count is loaded before the life counter: Pool.Get bumps the life before
+// setting Ref=1, so a reissued object is never mistaken for a retired one.
+func (l *retiredList[T, PT]) live(e retiredEntry[T]) (int64, bool) {
+	n := PT(e.obj).refs()
+	if n <= 0 || PT(e.obj).life() != e.life {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// pruneLocked drops stale entries. l.mu must be held.
+func (l *retiredList[T, PT]) pruneLocked() {
+	kept := l.entries[:0]
+	for _, e := range l.entries {
+		if _, ok := l.live(e); ok {
+			kept = append(kept, e)
+		}
+	}
+	clear(l.entries[len(kept):])
+	l.entries = kept
+}
+
+// add records objs, still referenced by the container, as retired;
+// an object that is already recorded is not added again.
+func (l *retiredList[T, PT]) add(objs []*T) {
+	at := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pruneLocked()
+	for _, obj := range objs {
+		if !slices.ContainsFunc(l.entries, func(e retiredEntry[T]) bool { return e.obj == obj }) {
+			l.entries = append(l.entries, retiredEntry[T]{obj: obj, life: PT(obj).life(), at: at})
+		}
+	}
+}
+
+// retire drops the container's reference to a displaced object,
+// and records it if readers still hold it.
+func (c *Container[T, PT]) retire(obj *T) {
+	life := PT(obj).life() // before dropping our ref: afterwards the object may be reissued
+	if refs := PT(obj).addRef(-1); refs <= 0 {
+		c.pool.released(obj, refs)
+
+		return
+	}
+	if c.inHistory(obj) { // the history outlives readers; it is retired again on eviction
+		return
+	}
+	at := time.Now()
+
+	c.retired.mu.Lock()
+	c.retired.pruneLocked()
+	c.retired.entries = append(c.retired.entries, retiredEntry[T]{obj: obj, life: life, at: at})
+	c.retired.mu.Unlock()
+}
+
+// RetiredCount returns the number of objects that were swapped out of the
+// container but are still held by readers (i.e. have not yet been returned to the pool).
+// Objects kept by WithHistory are not counted until they are evicted from the history.
+func (c *Container[T, PT]) RetiredCount() int {
+	c.retired.mu.Lock()
+	defer c.retired.mu.Unlock()
+
+	c.retired.pruneLocked()
+
+	return len(c.retired.entries)
+}
+
+// ForEachRetired calls fn for each object that was swapped out of the container
+// but is still held by readers, oldest first, with its outstanding reference count
+// and the time since it was retired. Use it to find which old generation
+// is keeping memory alive. Objects kept by WithHistory are skipped, as in RetiredCount.
+//
+// The set is snapshotted under a lock, and fn is called after the lock is released.
+// fn does not own a reference: the object may drain and be reused at any moment,
+// so treat obj as an identity for diagnostics only.
+func (c *Container[T, PT]) ForEachRetired(fn func(obj *T, outstanding int, age time.Duration)) {
+	type snapshot struct {
+		obj         *T
+		outstanding int64
+		at          time.Time
+	}
+
+	c.retired.mu.Lock()
+	c.retired.pruneLocked()
+	snap := make([]snapshot, 0, len(c.retired.entries))
+	for _, e := range c.retired.entries {
+		if n, ok := c.retired.live(e); ok {
+			snap = append(snap, snapshot{obj: e.obj, outstanding: n, at: e.at})
+		}
+	}
+	c.retired.mu.Unlock()
+
+	now := time.Now()
+	for _, s := range snap {
+		fn(s.obj, int(s.outstanding), now.Sub(s.at))
+	}
+}
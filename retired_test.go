@@ -0,0 +1,82 @@
+package poolswap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestForEachRetired(t *testing.T) {
+	pool := newMockPool()
+	a, b, c := pool.Get(), pool.Get(), pool.Get()
+	container := poolswap.NewContainer(pool, a)
+
+	heldA1 := container.Acquire()
+	heldA2 := container.Acquire()
+	beforeA := time.Now()
+	container.Update(b)
+
+	time.Sleep(20 * time.Millisecond)
+
+	heldB := container.Acquire()
+	beforeB := time.Now()
+	container.Update(c)
+
+	if got := container.RetiredCount(); got != 2 {
+		t.Fatalf("want 2 retired objects, got %d", got)
+	}
+
+	type report struct {
+		obj         *MockPayload
+		outstanding int
+		age         time.Duration
+	}
+	var reports []report
+	container.ForEachRetired(func(obj *MockPayload, outstanding int, age time.Duration) {
+		reports = append(reports, report{obj, outstanding, age})
+	})
+
+	if len(reports) != 2 {
+		t.Fatalf("want 2 reports, got %d", len(reports))
+	}
+	if reports[0].obj != a || reports[0].outstanding != 2 {
+		t.Fatalf("first report: want a with 2 refs, got %+v", reports[0])
+	}
+	if reports[1].obj != b || reports[1].outstanding != 1 {
+		t.Fatalf("second report: want b with 1 ref, got %+v", reports[1])
+	}
+	if maxAge := time.Since(beforeA); reports[0].age < 20*time.Millisecond || reports[0].age > maxAge {
+		t.Fatalf("a's age %v not in [20ms, %v]", reports[0].age, maxAge)
+	}
+	if maxAge := time.Since(beforeB); reports[1].age > maxAge {
+		t.Fatalf("b's age %v exceeds %v", reports[1].age, maxAge)
+	}
+
+	container.Release(heldA1)
+	container.Release(heldA2)
+	if got := container.RetiredCount(); got != 1 {
+		t.Fatalf("want 1 retired object after a drained, got %d", got)
+	}
+
+	container.Release(heldB)
+	if got := container.RetiredCount(); got != 0 {
+		t.Fatalf("want 0 retired objects after all drained, got %d", got)
+	}
+}
+
+func TestRetiredCount_ReissuedObjectIsNotRetired(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	held := container.Acquire()
+	container.Update(pool.Get())
+	container.Release(held) // drains; the object goes back to the pool
+
+	// Whether or not sync.Pool hands the same object back, it must not count as retired.
+	again := pool.Get()
+	if got := container.RetiredCount(); got != 0 {
+		t.Fatalf("want 0 retired objects, got %d", got)
+	}
+	pool.Release(again)
+}
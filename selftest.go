@@ -0,0 +1,90 @@
+package poolswap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSelfTest is wrapped by the errors returned by Pool.SelfTest.
+var ErrSelfTest = errors.New("poolswap: self-test failed")
+
+// SelfTest checks the pool's wiring before it serves traffic, for fail-fast startup:
+// it constructs an object with the factory, runs Reset on it, and round-trips it through
+// Put and Get. It returns an error wrapping ErrSelfTest that describes the first anomaly:
+// a panicking or nil-returning factory, a missing or panicking Reset, a Reset that rejects
+// a fresh object (so no object would ever be pooled), or a free list that keeps nothing.
+//
+// For a pool without a factory there is nothing to construct, and SelfTest returns nil.
+// The object is constructed like any other (counted in Stats.News, reported to the
+// WithOnGrow callback), and the round trip shows up in Stats like any other Put, Get and
+// Release. The WithOnRetireSync hook runs on the Put, so a failing hook is reported as
+// a discarded object. Since it may run Reset, the factory and the hook more than once,
+// all of them must be side-effect free apart from the object.
+func (p *Pool[T, PT]) SelfTest() error {
+	if p.newFunc() == nil {
+		return nil
+	}
+
+	var err error
+	var obj *T
+	func() {
+		defer selfTestRecover(&err, "factory")
+		obj = p.construct()
+	}()
+	switch {
+	case err != nil:
+		return err
+	case obj == nil:
+		return fmt.Errorf("%w: factory returned nil", ErrSelfTest)
+	case p.Reset == nil:
+		return fmt.Errorf("%w: pool has no Reset function", ErrSelfTest)
+	}
+
+	var ok bool
+	func() {
+		defer selfTestRecover(&err, "Reset")
+		ok = p.Reset(obj)
+	}()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: Reset returned false for a fresh object, so no object would ever be pooled", ErrSelfTest)
+	}
+
+	return p.selfTestRoundTrip(obj)
+}
+
+// selfTestRoundTrip puts the clean obj into the pool and gets an object back.
+func (p *Pool[T, PT]) selfTestRoundTrip(obj *T) error {
+	discards := p.stats.discards.Load()
+	p.Put(obj)
+	if p.stats.discards.Load() != discards {
+		return fmt.Errorf("%w: the pool discarded a clean object instead of keeping it"+
+			" (e.g. WithMaxIdle(0) or a failing WithOnRetireSync hook)", ErrSelfTest)
+	}
+	var err error
+	func() {
+		defer selfTestRecover(&err, "Get")
+		obj = p.Get()
+	}()
+	switch {
+	case err != nil:
+		return err
+	case obj == nil:
+		return fmt.Errorf("%w: Get returned nil", ErrSelfTest)
+	case PT(obj).refs() != 1:
+		return fmt.Errorf("%w: Get returned an object with %d references, want 1", ErrSelfTest, PT(obj).refs())
+	}
+	p.Release(obj)
+
+	return nil
+}
+
+// selfTestRecover turns a panic in the named SelfTest step into an error stored in err.
+// It must be deferred directly.
+func selfTestRecover(err *error, step string) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%w: %s panicked: %v", ErrSelfTest, step, r)
+	}
+}
@@ -0,0 +1,63 @@
+package poolswap_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestSelfTest(t *testing.T) {
+	err := newMockPool().SelfTest()
+	if err != nil {
+		t.Fatalf("a healthy pool should pass, got %v", err)
+	}
+
+	noPooling := poolswap.NewPool(
+		func() *MockPayload { return new(MockPayload) },
+		func(*MockPayload) bool { return false },
+	)
+	err = noPooling.SelfTest()
+	if !errors.Is(err, poolswap.ErrSelfTest) || !strings.Contains(err.Error(), "no object would ever be pooled") {
+		t.Fatalf("a Reset returning false should be reported, got %v", err)
+	}
+
+	keepsNothing := newBoundedPool(poolswap.WithMaxIdle(0))
+	err = keepsNothing.SelfTest()
+	if !errors.Is(err, poolswap.ErrSelfTest) {
+		t.Fatalf("a free list keeping nothing should be reported, got %v", err)
+	}
+
+	panicky := poolswap.NewPool(
+		func() *MockPayload { panic("boom") },
+		func(*MockPayload) bool { return true },
+	)
+	err = panicky.SelfTest()
+	if !errors.Is(err, poolswap.ErrSelfTest) || !strings.Contains(err.Error(), "factory panicked") {
+		t.Fatalf("a panicking factory should be reported, got %v", err)
+	}
+
+	err = poolswap.NewPool[MockPayload](nil, nil).SelfTest()
+	if err != nil {
+		t.Fatalf("a pool without a factory has nothing to check, got %v", err)
+	}
+}
+
+func TestSelfTest_ConstructsLikeGet(t *testing.T) {
+	var grown []int
+	pool := newBoundedPool(poolswap.WithMaxIdle(1), poolswap.WithOnGrow(func(n int) { grown = append(grown, n) }))
+	err := pool.SelfTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := pool.Stats(); s.News != 1 || s.Puts != 2 || len(grown) != 1 {
+		t.Fatalf("the object should be constructed via the pool, News=%d Puts=%d OnGrow=%v", s.News, s.Puts, grown)
+	}
+
+	failing := newBoundedPool(poolswap.WithOnRetireSync(func(*MockPayload) error { return errFlush }))
+	err = failing.SelfTest()
+	if !errors.Is(err, poolswap.ErrSelfTest) {
+		t.Fatalf("a failing WithOnRetireSync hook should be reported, got %v", err)
+	}
+}
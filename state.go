@@ -0,0 +1,35 @@
+package poolswap
+
+// ContainerState is a snapshot of a Container for diagnostics. It holds counts and
+// the status, never the objects themselves, so it can be served on a debug endpoint.
+type ContainerState struct {
+	// Generation is the generation of the current object.
+	Generation uint64 `json:"generation"`
+	// Outstanding is the number of references readers hold on the current object.
+	Outstanding int64 `json:"outstanding"`
+	// Retired is the number of swapped-out objects still held by readers (see RetiredCount),
+	// not counting those kept by WithHistory.
+	Retired int `json:"retired"`
+	// Status is the container's lifecycle state.
+	Status Status `json:"status"`
+}
+
+// State returns a snapshot of the container's state.
+// The fields are read one after the other, so they may not be mutually consistent
+// under concurrent updates.
+func (c *Container[T, PT]) State() ContainerState {
+	c.mu.RLock()
+	gen := c.gen
+	var outstanding int64
+	if c.current != nil {
+		outstanding = c.current.refs() - 1 // minus the container's own reference
+	}
+	c.mu.RUnlock()
+
+	return ContainerState{
+		Generation:  gen,
+		Outstanding: outstanding,
+		Retired:     c.RetiredCount(),
+		Status:      c.Status(),
+	}
+}
@@ -0,0 +1,67 @@
+package poolswap_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestState(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	old := container.Acquire()
+	container.Update(pool.Get())
+	current := container.Acquire()
+
+	want := poolswap.ContainerState{Generation: 1, Outstanding: 1, Retired: 1, Status: poolswap.StatusActive}
+	if got := container.State(); got != want {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+
+	container.Release(old)
+	container.Release(current)
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	stats := poolswap.Stats{Gets: 1, News: 2, Puts: 3, Discards: 4}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"gets":1,"news":2,"puts":3,"discards":4}`; string(data) != want {
+		t.Fatalf("want %s, got %s", want, data)
+	}
+	var gotStats poolswap.Stats
+	err = json.Unmarshal(data, &gotStats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotStats != stats {
+		t.Fatalf("round trip: want %+v, got %+v", stats, gotStats)
+	}
+
+	state := poolswap.ContainerState{Generation: 7, Outstanding: 2, Retired: 1, Status: poolswap.StatusClosing}
+	data, err = json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"generation":7,"outstanding":2,"retired":1,"status":"closing"}`; string(data) != want {
+		t.Fatalf("want %s, got %s", want, data)
+	}
+	var gotState poolswap.ContainerState
+	err = json.Unmarshal(data, &gotState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotState != state {
+		t.Fatalf("round trip: want %+v, got %+v", state, gotState)
+	}
+
+	err = json.Unmarshal([]byte(`{"status":"bogus"}`), &gotState)
+	if !errors.Is(err, poolswap.ErrUnknownStatus) {
+		t.Fatalf("want unknown status error, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package poolswap
+
+import "sync/atomic"
+
+// Stats is a snapshot of a Pool's counters, counted since creation or the last
+// StatsAndReset. The JSON field names are stable, e.g. for scraping them over time.
+type Stats struct {
+	// Gets is the number of objects handed out by Get.
+	Gets uint64 `json:"gets"`
+	// News is the number of objects allocated by the factory.
+	News uint64 `json:"news"`
+	// Puts is the number of drained objects that were reset and returned to the pool.
+	Puts uint64 `json:"puts"`
+	// Discards is the number of drained objects dropped because Reset returned false
+	// (or because they predate a Recycle, or the free list was full; see WithMaxIdle).
+	Discards uint64 `json:"discards"`
+}
+
+type poolStats struct {
+	gets     atomic.Uint64
+	news     atomic.Uint64
+	puts     atomic.Uint64
+	discards atomic.Uint64
+}
+
+func newPoolStats() poolStats {
+	return poolStats{
+		gets:     atomic.Uint64{},
+		news:     atomic.Uint64{},
+		puts:     atomic.Uint64{},
+		discards: atomic.Uint64{},
+	}
+}
+
+// Stats returns the cumulative counters since the pool was created
+// (or since the last call to StatsAndReset).
+//
+// Each counter is read atomically, but the snapshot as a whole is not:
+// operations running concurrently may be reflected in some counters and not others.
+func (p *Pool[T, PT]) Stats() Stats {
+	return Stats{
+		Gets:     p.stats.gets.Load(),
+		News:     p.stats.news.Load(),
+		Puts:     p.stats.puts.Load(),
+		Discards: p.stats.discards.Load(),
+	}
+}
+
+// StatsAndReset returns the counters and atomically zeroes each of them,
+// so that successive calls report the delta since the previous call.
+// No concurrent update is lost: every increment is counted by exactly one call.
+//
+// StatsAndReset and Stats share the same counters, so mixing cumulative
+// (Stats) and delta (StatsAndReset) reporting on the same pool will
+// interfere: Stats only reports what happened since the last reset.
+func (p *Pool[T, PT]) StatsAndReset() Stats {
+	return Stats{
+		Gets:     p.stats.gets.Swap(0),
+		News:     p.stats.news.Swap(0),
+		Puts:     p.stats.puts.Swap(0),
+		Discards: p.stats.discards.Swap(0),
+	}
+}
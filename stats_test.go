@@ -0,0 +1,68 @@
+package poolswap_test
+
+import (
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestStatsAndReset(t *testing.T) {
+	pool := newMockPool()
+
+	if got := pool.StatsAndReset(); got != (poolswap.Stats{}) {
+		t.Fatalf("fresh pool should have zero stats, got %+v", got)
+	}
+
+	a, b, c := pool.Get(), pool.Get(), pool.Get()
+	pool.Release(a)
+	pool.Release(b)
+	pool.Reset = func(_ *MockPayload) bool { return false }
+	pool.Release(c)
+
+	want := poolswap.Stats{Gets: 3, News: 3, Puts: 2, Discards: 1}
+	if got := pool.StatsAndReset(); got != want {
+		t.Fatalf("first interval: want %+v, got %+v", want, got)
+	}
+
+	if got := pool.StatsAndReset(); got != (poolswap.Stats{}) {
+		t.Fatalf("empty interval should report zero stats, got %+v", got)
+	}
+
+	d := pool.Get()
+	pool.Release(d)
+
+	got := pool.StatsAndReset()
+	if got.Gets != 1 || got.Discards != 1 || got.Puts != 0 {
+		t.Fatalf("second interval: want Gets=1 Discards=1 Puts=0, got %+v", got)
+	}
+
+	if got := pool.Stats(); got != (poolswap.Stats{}) {
+		t.Fatalf("Stats after StatsAndReset should be zero, got %+v", got)
+	}
+}
+
+func TestWithOnGrow(t *testing.T) {
+	var sizes []int
+	pool := poolswap.NewPool(
+		func() *MockPayload { return &MockPayload{} },
+		func(_ *MockPayload) bool { return true },
+		poolswap.WithOnGrow(func(newSize int) { sizes = append(sizes, newSize) }),
+	)
+
+	// Nothing pooled yet, so every Get has to construct.
+	objs := []*MockPayload{pool.Get(), pool.Get(), pool.Get()}
+	if len(sizes) != 3 || sizes[0] != 1 || sizes[1] != 2 || sizes[2] != 3 {
+		t.Fatalf("want OnGrow(1), OnGrow(2), OnGrow(3), got %v", sizes)
+	}
+
+	pool.StatsAndReset() // must not affect the running total
+	for _, obj := range objs {
+		pool.Release(obj)
+	}
+	for range 3 {
+		defer pool.Release(pool.Get())
+	}
+	if got := int(pool.Stats().News); len(sizes) != 3+got || sizes[len(sizes)-1] != 3+got {
+		t.Fatalf("OnGrow should fire once per construction with the running total, got %v (News=%d)", sizes, got)
+	}
+}
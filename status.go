@@ -0,0 +1,119 @@
+package poolswap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Status describes a Container's lifecycle state.
+//
+// The allowed transitions are:
+//
+//	StatusActive  -> StatusClosing  (Close)
+//	StatusClosing -> StatusClosed   (the last retired object is released, right away
+//	                                 if Close had nothing outstanding)
+//
+// A container never returns to an earlier state.
+type Status int32 //nolint:recvcheck // UnmarshalText needs a pointer receiver
+
+// ErrClosed is returned by operations that cannot complete because the container is closed.
+var ErrClosed = errors.New("poolswap: container is closed")
+
+// ErrNoCurrent is returned by operations that need a current object when the container is empty.
+var ErrNoCurrent = errors.New("poolswap: container has no current object")
+
+// ErrUnknownStatus is returned when decoding an unknown Status name.
+var ErrUnknownStatus = errors.New("poolswap: unknown status")
+
+const (
+	// StatusActive is the normal state: Acquire returns the current object and Update installs new ones.
+	StatusActive Status = iota
+	// StatusClosing means Close was called but readers still hold retired objects.
+	// Acquire returns nil and Update releases the given object instead of installing it.
+	StatusClosing
+	// StatusClosed means Close was called and every object has been released.
+	// Acquire and Update behave as in StatusClosing.
+	StatusClosed
+)
+
+// String returns the status name.
+func (s Status) String() string {
+	switch s {
+	case StatusActive:
+		return "active"
+	case StatusClosing:
+		return "closing"
+	case StatusClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the status as its name, e.g. for JSON.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a status name produced by MarshalText.
+func (s *Status) UnmarshalText(text []byte) error {
+	for _, v := range []Status{StatusActive, StatusClosing, StatusClosed} {
+		if string(text) == v.String() {
+			*s = v
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w %q", ErrUnknownStatus, text)
+}
+
+// Status returns the container's lifecycle state.
+func (c *Container[T, PT]) Status() Status {
+	c.mu.RLock()
+	status := c.status
+	c.mu.RUnlock()
+	if status != StatusClosing || c.RetiredCount() > 0 {
+		return status
+	}
+
+	c.mu.Lock()
+	c.status = StatusClosed // nothing is retired after Close, so this is final
+	c.mu.Unlock()
+
+	return StatusClosed
+}
+
+// Close shuts the container down gracefully: it empties the container and
+// releases its reference to the current object (and any object kept by RecycleInto).
+// Readers holding references keep using them; the container reports
+// StatusClosing until they have all been released, then StatusClosed.
+//
+// After Close, Acquire returns nil and Update (and its variants) release the
+// given object to the pool instead of installing it. Close is idempotent.
+func (c *Container[T, PT]) Close() {
+	c.mu.Lock()
+	if c.status != StatusActive {
+		c.mu.Unlock()
+
+		return
+	}
+	c.status = StatusClosing
+	objs := c.clearHistoryLocked()
+	for _, obj := range []*T{c.current, c.spare} {
+		if obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	c.current, c.spare = nil, nil
+	c.peak.Store(0)
+	// Counted as retired before the lock is released, so that Status
+	// cannot report StatusClosed until the references below are dropped.
+	c.retired.add(objs)
+	c.mu.Unlock()
+
+	for _, obj := range objs {
+		c.pool.released(obj, PT(obj).addRef(-1))
+	}
+	c.notify()
+}
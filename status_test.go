@@ -0,0 +1,133 @@
+package poolswap_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestStatus_GracefulClose(t *testing.T) {
+	pool := newMockPool()
+	initial := pool.Get()
+	initial.Recycled.Store(false)
+	container := poolswap.NewContainer(pool, initial)
+
+	if got := container.Status(); got != poolswap.StatusActive {
+		t.Fatalf("want %v, got %v", poolswap.StatusActive, got)
+	}
+
+	held := container.Acquire()
+	container.Close()
+
+	if got := container.Status(); got != poolswap.StatusClosing {
+		t.Fatalf("want %v while a reader holds a reference, got %v", poolswap.StatusClosing, got)
+	}
+	if container.Acquire() != nil {
+		t.Fatal("Acquire on a closing container should return nil")
+	}
+
+	rejected := pool.Get()
+	rejected.Recycled.Store(false)
+	if gen := container.UpdateG(rejected); gen != 0 {
+		t.Fatalf("Update on a closing container should be rejected, got gen %d", gen)
+	}
+	if !rejected.Recycled.Load() {
+		t.Fatal("rejected object should be returned to the pool")
+	}
+	if container.Acquire() != nil {
+		t.Fatal("rejected object must not be installed")
+	}
+
+	if held.Recycled.Load() {
+		t.Fatal("held object must not be reset while closing")
+	}
+	container.Release(held)
+
+	if got := container.Status(); got != poolswap.StatusClosed {
+		t.Fatalf("want %v after the last release, got %v", poolswap.StatusClosed, got)
+	}
+	if !held.Recycled.Load() {
+		t.Fatal("object should be returned to the pool after the last release")
+	}
+
+	container.Close() // idempotent
+	if got := container.Status(); got != poolswap.StatusClosed {
+		t.Fatalf("want %v after a second Close, got %v", poolswap.StatusClosed, got)
+	}
+}
+
+func TestStatus_CloseWithoutReaders(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	container.Close()
+	if got := container.Status(); got != poolswap.StatusClosed {
+		t.Fatalf("want %v, got %v", poolswap.StatusClosed, got)
+	}
+}
+
+func TestStatus_NeverGoesBackDuringClose(t *testing.T) {
+	for range 200 {
+		pool := newMockPool()
+		container := poolswap.NewContainer(pool, pool.Get())
+		held := container.Acquire()
+
+		stop := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			last := poolswap.StatusActive
+			for {
+				got := container.Status()
+				if got < last {
+					t.Errorf("status went back from %v to %v", last, got)
+
+					return
+				}
+				last = got
+				select {
+				case <-stop:
+					return
+				default:
+				}
+			}
+		})
+
+		container.Close()
+		if got := container.Status(); got != poolswap.StatusClosing {
+			t.Fatalf("want %v while a reader holds a reference, got %v", poolswap.StatusClosing, got)
+		}
+		container.Release(held)
+		close(stop)
+		wg.Wait()
+	}
+}
+
+func TestStatus_ClosingWhileCloseRetires(t *testing.T) {
+	var container *poolswap.Container[MockPayload, *MockPayload]
+	var closing bool
+	var seen []poolswap.Status
+	pool := poolswap.NewPool(
+		func() *MockPayload { return new(MockPayload) },
+		func(*MockPayload) bool {
+			if closing {
+				seen = append(seen, container.Status()) // sampled from inside Close
+			}
+
+			return true
+		},
+	)
+	container = poolswap.NewContainer(pool, pool.Get())
+	held := container.Acquire()
+	container.RecycleInto(func(*MockPayload) {}) // held object becomes the spare
+
+	closing = true
+	container.Close() // drains the unheld current (sampling), then retires the held spare
+	closing = false
+	seen = append(seen, container.Status())
+	container.Release(held)
+
+	if len(seen) != 2 || seen[0] != poolswap.StatusClosing || seen[1] != poolswap.StatusClosing {
+		t.Fatalf("want Closing throughout Close, got %v", seen)
+	}
+}
@@ -0,0 +1,72 @@
+package poolswap
+
+import (
+	"sync/atomic"
+	"weak"
+)
+
+// Subscription is the handle of a weak subscription created by Container.SubscribeWeak.
+// The subscription stays active only while the handle is reachable: once the handle
+// is garbage collected, the container drops the subscription.
+// It does not reference the container.
+type Subscription struct {
+	fn      func()
+	stopped atomic.Bool
+}
+
+// Stop ends the subscription without waiting for the handle to be collected.
+func (s *Subscription) Stop() { s.stopped.Store(true) }
+
+// SubscribeWeak registers fn to be called after every change of the current object
+// (Update and its variants), for as long as the returned handle is reachable.
+// This lets an observer that forgets to unsubscribe get cleaned up with it,
+// instead of leaking via the container.
+//
+// fn is called synchronously on the updating goroutine, after the swap.
+// The container only references fn through the handle, so fn may capture the handle.
+func (c *Container[T, PT]) SubscribeWeak(fn func()) *Subscription {
+	h := &Subscription{fn: fn, stopped: atomic.Bool{}}
+
+	c.subsMu.Lock()
+	c.pruneSubsLocked()
+	c.subs = append(c.subs, weak.Make(h))
+	c.subsMu.Unlock()
+
+	return h
+}
+
+// pruneSubsLocked drops subscriptions whose handle was collected or stopped. c.subsMu must be held.
+func (c *Container[T, PT]) pruneSubsLocked() {
+	kept := c.subs[:0]
+	for _, s := range c.subs {
+		if h := s.Value(); h != nil && !h.stopped.Load() {
+			kept = append(kept, s)
+		}
+	}
+	clear(c.subs[len(kept):])
+	c.subs = kept
+}
+
+// notify calls the live subscribers.
+func (c *Container[T, PT]) notify() {
+	c.subsMu.Lock()
+	if len(c.subs) == 0 {
+		c.subsMu.Unlock()
+
+		return
+	}
+	handles := make([]*Subscription, 0, len(c.subs))
+	for _, s := range c.subs {
+		if h := s.Value(); h != nil {
+			handles = append(handles, h)
+		}
+	}
+	c.pruneSubsLocked()
+	c.subsMu.Unlock()
+
+	for _, h := range handles {
+		if !h.stopped.Load() {
+			h.fn()
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package poolswap_test
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestSubscribeWeak(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	var calls atomic.Int64
+	handle := container.SubscribeWeak(func() { calls.Add(1) })
+
+	container.Update(pool.Get())
+	if calls.Load() != 1 {
+		t.Fatalf("subscriber should be notified of an update, calls=%d", calls.Load())
+	}
+	runtime.KeepAlive(handle)
+
+	handle = nil //nolint:ineffassign,wastedassign // drop the last reference
+	runtime.GC()
+
+	container.Update(pool.Get())
+	if calls.Load() != 1 {
+		t.Fatalf("subscription should be dropped once its handle is collected, calls=%d", calls.Load())
+	}
+}
+
+func TestSubscribeWeak_Stop(t *testing.T) {
+	pool := newMockPool()
+	container := poolswap.NewContainer(pool, pool.Get())
+
+	var calls atomic.Int64
+	handle := container.SubscribeWeak(func() { calls.Add(1) })
+	handle.Stop()
+
+	container.Update(pool.Get())
+	if calls.Load() != 0 {
+		t.Fatalf("stopped subscription should not fire, calls=%d", calls.Load())
+	}
+}
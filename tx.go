@@ -0,0 +1,132 @@
+package poolswap
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrTxUnavailable is returned by TxAcquire when the container has moved past the
+	// generation captured by the transaction and no longer retains it (see WithHistory).
+	ErrTxUnavailable = errors.New("poolswap: generation captured by the transaction is no longer available")
+	// ErrNotInTx is returned by TxAcquire for a container that was not in the group at Begin.
+	ErrNotInTx = errors.New("poolswap: container is not part of the transaction")
+)
+
+// TxMember is a container that can be registered with a TxGroup; every Container is one.
+type TxMember interface {
+	Generation() uint64
+}
+
+// TxGroup coordinates reads of related containers as a consistent set: Begin captures
+// the generation of every member, and TxAcquire then returns each member's object as of
+// Begin, from its history if it has advanced since (so members should use WithHistory).
+//
+// Begin is atomic with respect to Write: writers that update several members inside
+// Write are seen by a transaction either completely or not at all. Updates outside of
+// Write are captured as they happen to land, so the set is only consistent for writers
+// that go through Write. A Reset restarts a member's generations, which a transaction
+// spanning it cannot detect.
+type TxGroup struct {
+	mu      sync.RWMutex
+	members []TxMember
+}
+
+// Tx is a set of generations captured by TxGroup.Begin.
+type Tx struct {
+	gens map[TxMember]uint64
+}
+
+// NewTxGroup creates an empty TxGroup.
+func NewTxGroup() *TxGroup {
+	return &TxGroup{
+		mu:      sync.RWMutex{},
+		members: nil,
+	}
+}
+
+// Add registers c with the group. Adding a container twice has no effect.
+func (g *TxGroup) Add(c TxMember) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !slices.Contains(g.members, c) {
+		g.members = append(g.members, c)
+	}
+}
+
+// Remove unregisters c. It is a no-op for containers not in the group.
+func (g *TxGroup) Remove(c TxMember) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if i := slices.Index(g.members, c); i >= 0 {
+		g.members = slices.Delete(g.members, i, i+1)
+	}
+}
+
+// Write runs fn, which updates members of the group, so that no transaction begins
+// while it runs. fn must not call Begin.
+func (g *TxGroup) Write(fn func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	fn()
+}
+
+// Begin starts a transaction by capturing the current generation of every member.
+func (g *TxGroup) Begin() *Tx {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	tx := &Tx{gens: make(map[TxMember]uint64, len(g.members))}
+	for _, m := range g.members {
+		tx.gens[m] = m.Generation()
+	}
+
+	return tx
+}
+
+// TxAcquire acquires c's object as of tx's Begin: the current object if c has not been
+// updated since, otherwise the one kept in c's history (WithHistory). The caller owns the
+// reference and must release it, as with Acquire. The result is nil if c was empty at Begin.
+//
+// Returns ErrTxUnavailable if c no longer retains that generation,
+// and ErrNotInTx if c was not in the group at Begin.
+func TxAcquire[T any, PT PtrRef[T]](tx *Tx, c *Container[T, PT]) (*T, error) {
+	gen, ok := tx.gens[c]
+	if !ok {
+		return nil, ErrNotInTx
+	}
+	obj, ok := c.acquireGeneration(gen)
+	if !ok {
+		return nil, ErrTxUnavailable
+	}
+
+	return obj, nil
+}
+
+// acquireGeneration acquires the object of generation gen, if it is current or in the history.
+// It reports false if the container has neither; the object is nil if gen was an empty state.
+func (c *Container[T, PT]) acquireGeneration(gen uint64) (*T, bool) {
+	c.rlock()
+	defer c.mu.RUnlock()
+
+	if c.gen == gen && c.status == StatusActive {
+		if c.current != nil {
+			c.current.addRef(1)
+		}
+
+		return c.current, true
+	}
+	for _, e := range c.history.entries {
+		if e.gen == gen {
+			PT(e.obj).addRef(1)
+
+			return e.obj, true
+		}
+	}
+
+	return nil, false
+}
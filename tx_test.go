@@ -0,0 +1,94 @@
+package poolswap_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestTxGroup_Consistent(t *testing.T) {
+	pool := newMockPool()
+	a := poolswap.NewContainer(pool, &MockPayload{ID: 0}, poolswap.WithHistory(4))
+	b := poolswap.NewContainer(pool, &MockPayload{ID: 0}, poolswap.WithHistory(4))
+	group := poolswap.NewTxGroup()
+	group.Add(a)
+	group.Add(b)
+
+	var wg sync.WaitGroup
+	var stop atomic.Bool
+	wg.Go(func() {
+		for i := int64(1); !stop.Load(); i++ {
+			objA, objB := pool.Get(), pool.Get()
+			objA.ID, objB.ID = i, i
+			group.Write(func() {
+				a.Update(objA)
+				b.Update(objB)
+			})
+		}
+	})
+
+	var consistent, unavailable int
+	for range 2000 {
+		tx := group.Begin()
+		gotA, errA := poolswap.TxAcquire(tx, a)
+		gotB, errB := poolswap.TxAcquire(tx, b)
+		switch {
+		case errA == nil && errB == nil:
+			if gotA.ID != gotB.ID {
+				t.Fatalf("inconsistent read: a=%d b=%d", gotA.ID, gotB.ID)
+			}
+			consistent++
+		case errors.Is(errA, poolswap.ErrTxUnavailable) || errors.Is(errB, poolswap.ErrTxUnavailable):
+			unavailable++
+		default:
+			t.Fatalf("unexpected errors: %v, %v", errA, errB)
+		}
+		a.Release(gotA)
+		b.Release(gotB)
+	}
+	stop.Store(true)
+	wg.Wait()
+
+	if consistent == 0 {
+		t.Fatalf("no consistent read succeeded (%d unavailable)", unavailable)
+	}
+}
+
+func TestTxGroup_Unavailable(t *testing.T) {
+	pool := newMockPool()
+	a := poolswap.NewContainer(pool, pool.Get(), poolswap.WithHistory(1))
+	other := poolswap.NewContainer(pool, pool.Get())
+	group := poolswap.NewTxGroup()
+	group.Add(a)
+
+	tx := group.Begin()
+	first := a.Acquire()
+	a.Release(first)
+
+	a.Update(pool.Get())
+	got, err := poolswap.TxAcquire(tx, a)
+	if err != nil || got != first {
+		t.Fatalf("the captured generation should come from the history, got %v", err)
+	}
+	a.Release(got)
+
+	a.Update(pool.Get()) // pushes the captured generation out of the history
+	_, err = poolswap.TxAcquire(tx, a)
+	if !errors.Is(err, poolswap.ErrTxUnavailable) {
+		t.Fatalf("want ErrTxUnavailable, got %v", err)
+	}
+
+	_, err = poolswap.TxAcquire(tx, other)
+	if !errors.Is(err, poolswap.ErrNotInTx) {
+		t.Fatalf("want ErrNotInTx, got %v", err)
+	}
+
+	group.Remove(a)
+	_, err = poolswap.TxAcquire(group.Begin(), a)
+	if !errors.Is(err, poolswap.ErrNotInTx) {
+		t.Fatalf("a removed container should not be captured, got %v", err)
+	}
+}
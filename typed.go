@@ -0,0 +1,36 @@
+package poolswap
+
+// Typed adapts an arbitrary value for pooling, so simple types (buffers, maps, ...)
+// can be used without declaring a struct that embeds Ref.
+type Typed[T any] struct {
+	Ref
+
+	Value T
+}
+
+// NewTypedPool creates a pool of *Typed[T].
+// newValue returns the initial Value of a newly allocated object; if nil, new objects
+// start with the zero T (unlike NewPool, where a nil factory means "never allocate").
+// reset prepares a used Value for reuse (or returns false to discard it).
+func NewTypedPool[T any](newValue func() T, reset func(*T) bool, opts ...PoolOption) *Pool[Typed[T], *Typed[T]] {
+	return NewPool[Typed[T], *Typed[T]](
+		func() *Typed[T] {
+			t := new(Typed[T])
+			if newValue != nil {
+				t.Value = newValue()
+			}
+
+			return t
+		},
+		func(t *Typed[T]) bool { return reset(&t.Value) },
+		opts...,
+	)
+}
+
+// NewTypedContainer creates a container of *Typed[T] over a new typed pool (see NewTypedPool),
+// starting from a fresh object.
+func NewTypedContainer[T any](newValue func() T, reset func(*T) bool) *Container[Typed[T], *Typed[T]] {
+	pool := NewTypedPool(newValue, reset)
+
+	return NewContainer(pool, pool.Get())
+}
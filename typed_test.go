@@ -0,0 +1,63 @@
+package poolswap_test
+
+import (
+	"testing"
+
+	"github.com/keilerkonzept/poolswap"
+)
+
+func TestTypedPool_Bytes(t *testing.T) {
+	const maxRetained = 1 << 10
+	pool := poolswap.NewTypedPool(nil, func(b *[]byte) bool {
+		*b = (*b)[:0]
+		return cap(*b) <= maxRetained // don't keep oversized buffers around
+	})
+
+	buf := pool.Get()
+	if buf.Value != nil || buf.DebugPeekRef() != 1 {
+		t.Fatalf("new Typed[[]byte] should start with a nil slice and Ref=1, got %v", buf.Value)
+	}
+	buf.Value = append(buf.Value, "hello"...)
+	pool.Release(buf)
+	if len(buf.Value) != 0 {
+		t.Fatal("reset should have truncated the buffer")
+	}
+
+	big := pool.Get()
+	big.Value = make([]byte, 0, 2*maxRetained)
+	pool.Release(big)
+	if s := pool.Stats(); s.Puts != 1 || s.Discards != 1 {
+		t.Fatalf("want the small buffer pooled and the big one discarded, got %+v", s)
+	}
+}
+
+func TestTypedContainer_Map(t *testing.T) {
+	container := poolswap.NewTypedContainer(
+		func() map[string]string { return make(map[string]string) },
+		func(m *map[string]string) bool {
+			clear(*m)
+			return true
+		},
+	)
+
+	next := container.GetNew()
+	next.Value["key"] = "v1"
+	container.Update(next)
+
+	held := container.Acquire()
+	if held.Value["key"] != "v1" {
+		t.Fatalf("want v1, got %q", held.Value["key"])
+	}
+
+	newer := container.GetNew()
+	newer.Value["key"] = "v2"
+	container.Update(newer)
+
+	if held.Value["key"] != "v1" {
+		t.Fatal("held value must not change while referenced")
+	}
+	container.Release(held)
+	if len(held.Value) != 0 {
+		t.Fatal("released map should have been cleared by reset")
+	}
+}